@@ -0,0 +1,56 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/larksuite/base-sdk-go/v3"
+	"github.com/larksuite/base-sdk-go/v3/core"
+	"github.com/larksuite/base-sdk-go/v3/service/drive/v1"
+)
+
+// POST /open-apis/drive/v1/files/:file_token/copy
+func main() {
+	// 创建 Client
+	// 全局baseAppToken,如果builder中有也设置了全局appToken，以build中为准
+	client := lark.NewClient("personalBaseToken", "appToken")
+	// 创建请求对象
+	req := larkdrive.NewCopyFileReqBuilder().
+		FileToken("boxcnrHpsg1QDqXAAAyachabcef").
+		UserIdType("open_id").
+		Body(larkdrive.NewCopyFileReqBodyBuilder().
+			Name("test.txt").
+			Type("bitable").
+			FolderToken("fldbcO1UuPz8VwnpPx5a92abcef").
+			Extra([]*larkdrive.Property{larkdrive.NewPropertyBuilder().Build()}).
+			Build()).
+		Build()
+	// 发起请求
+	resp, err := client.Drive.File.Copy(context.Background(), req)
+
+	// 处理错误
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// 服务端错误处理
+	if !resp.Success() {
+		fmt.Println(resp.Code, resp.Msg, resp.RequestId())
+		return
+	}
+
+	// 业务处理
+	fmt.Println(larkcore.Prettify(resp))
+}
@@ -16,6 +16,7 @@ package larkbase
 import (
 	"context"
 	"net/http"
+	"sync"
 
 	"github.com/larksuite/base-sdk-go/v3/core"
 )
@@ -47,6 +48,11 @@ type BaseService struct {
 	AppTableFormField *appTableFormField // 表单
 	AppTableRecord    *appTableRecord    // 记录
 	AppTableView      *appTableView      // 视图
+
+	recordFillHooks  []RecordWriteHook
+	recordWriteHooks []RecordWriteHook
+	schemasOnce      sync.Once
+	schemas          *tableSchemaStore
 }
 
 type app struct {
@@ -927,6 +933,9 @@ func (a *appTableRecord) BatchCreate(ctx context.Context, req *BatchCreateAppTab
 	apiReq.ApiPath = "/open-apis/bitable/v1/apps/:app_token/tables/:table_id/records/batch_create"
 	apiReq.HttpMethod = http.MethodPost
 	apiReq.SupportedAccessTokenTypes = []larkcore.AccessTokenType{larkcore.AccessTokenTypePersonal}
+	if err := a.service.beforeRecordWrite(ctx, apiReq); err != nil {
+		return nil, err
+	}
 	apiResp, err := larkcore.Request(ctx, apiReq, a.service.config, options...)
 	if err != nil {
 		return nil, err
@@ -983,6 +992,9 @@ func (a *appTableRecord) BatchUpdate(ctx context.Context, req *BatchUpdateAppTab
 	apiReq.ApiPath = "/open-apis/bitable/v1/apps/:app_token/tables/:table_id/records/batch_update"
 	apiReq.HttpMethod = http.MethodPost
 	apiReq.SupportedAccessTokenTypes = []larkcore.AccessTokenType{larkcore.AccessTokenTypePersonal}
+	if err := a.service.beforeRecordWrite(ctx, apiReq); err != nil {
+		return nil, err
+	}
 	apiResp, err := larkcore.Request(ctx, apiReq, a.service.config, options...)
 	if err != nil {
 		return nil, err
@@ -1011,6 +1023,9 @@ func (a *appTableRecord) Create(ctx context.Context, req *CreateAppTableRecordRe
 	apiReq.ApiPath = "/open-apis/bitable/v1/apps/:app_token/tables/:table_id/records"
 	apiReq.HttpMethod = http.MethodPost
 	apiReq.SupportedAccessTokenTypes = []larkcore.AccessTokenType{larkcore.AccessTokenTypePersonal}
+	if err := a.service.beforeRecordWrite(ctx, apiReq); err != nil {
+		return nil, err
+	}
 	apiResp, err := larkcore.Request(ctx, apiReq, a.service.config, options...)
 	if err != nil {
 		return nil, err
@@ -1131,6 +1146,9 @@ func (a *appTableRecord) Update(ctx context.Context, req *UpdateAppTableRecordRe
 	apiReq.ApiPath = "/open-apis/bitable/v1/apps/:app_token/tables/:table_id/records/:record_id"
 	apiReq.HttpMethod = http.MethodPut
 	apiReq.SupportedAccessTokenTypes = []larkcore.AccessTokenType{larkcore.AccessTokenTypePersonal}
+	if err := a.service.beforeRecordWrite(ctx, apiReq); err != nil {
+		return nil, err
+	}
 	apiResp, err := larkcore.Request(ctx, apiReq, a.service.config, options...)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,315 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+	"github.com/larksuite/base-sdk-go/v3/service/drive/v1"
+)
+
+// 下载素材接口的调用频率上限为 5QPS
+const attachmentDownloadInterval = time.Second / 5
+
+// 附件导出目录下的清单文件名
+const attachmentManifestName = "manifest.json"
+
+// AttachmentManifestItem 单个附件的下载结果
+type AttachmentManifestItem struct {
+	RecordId  string `json:"record_id"`  // 记录ID
+	FieldName string `json:"field_name"` // 附件字段名
+	FileToken string `json:"file_token"` // 附件 token
+	Name      string `json:"name"`       // 附件名
+	Path      string `json:"path"`       // 相对于导出目录的文件路径
+	Size      int64  `json:"size"`       // 文件大小（以字节为单位）
+	Sha256    string `json:"sha256"`     // 文件内容的 sha256
+	Skipped   bool   `json:"-"`          // 本地已存在且校验通过，本次未重新下载
+	Err       error  `json:"-"`          // 下载或校验失败的原因
+}
+
+// AttachmentManifest 一张数据表的附件导出清单
+type AttachmentManifest struct {
+	TableId string                    `json:"table_id"` // 数据表ID
+	Items   []*AttachmentManifestItem `json:"items"`    // 下载成功的附件
+}
+
+// AttachmentDownloader 将数据表中全部附件字段的文件下载到本地
+//
+// - 文件按 table_id/record_id/字段名/文件名 的目录结构存放，并在 table_id 目录下写入 manifest.json
+//
+// - 下载时校验文件大小并记录 sha256；再次执行时，清单中已有且校验通过的文件会被跳过
+type AttachmentDownloader struct {
+	service     *BaseService
+	dir         string
+	concurrency int
+	options     []larkcore.RequestOptionFunc
+}
+
+// 创建附件下载器，dir 为导出根目录，concurrency 为并发下载数（小于 1 时按 1 处理）
+func (b *BaseService) NewAttachmentDownloader(dir string, concurrency int, options ...larkcore.RequestOptionFunc) *AttachmentDownloader {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &AttachmentDownloader{service: b, dir: dir, concurrency: concurrency, options: options}
+}
+
+// 下载数据表中全部附件
+//
+// - 单个附件失败时记录在返回结果的 Err 中，不影响其它附件；清单只包含下载成功或跳过的附件
+//
+// - 仅在获取字段、记录或写入清单失败以及 ctx 结束时返回 error；ctx 结束时已完成的附件仍会写入清单
+func (d *AttachmentDownloader) Download(ctx context.Context, tableId string) ([]*AttachmentManifestItem, error) {
+	fieldNames, err := d.attachmentFieldNames(ctx, tableId)
+	if err != nil {
+		return nil, err
+	}
+	items, err := d.collect(ctx, tableId, fieldNames)
+	if err != nil {
+		return nil, err
+	}
+
+	tableDir := filepath.Join(d.dir, sanitizeFileName(tableId))
+	previous := d.readManifest(tableDir)
+
+	limiter := time.NewTicker(attachmentDownloadInterval)
+	defer limiter.Stop()
+
+	var wg sync.WaitGroup
+	queue := make(chan *AttachmentManifestItem)
+	for i := 0; i < d.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if old, ok := previous[item.Path]; ok && old.FileToken == item.FileToken && verifyFile(filepath.Join(tableDir, item.Path), old.Size, old.Sha256) {
+					item.Size, item.Sha256, item.Skipped = old.Size, old.Sha256, true
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					item.Err = ctx.Err()
+					continue
+				case <-limiter.C:
+				}
+				item.Err = d.download(ctx, tableDir, item)
+			}
+		}()
+	}
+	for _, item := range items {
+		queue <- item
+	}
+	close(queue)
+	wg.Wait()
+
+	// ctx 结束时同样写入清单，保留已完成的进度供下次续传
+	if err := d.writeManifest(tableDir, tableId, items); err != nil {
+		return items, err
+	}
+	return items, ctx.Err()
+}
+
+// 将下载成功或跳过的附件写入清单
+func (d *AttachmentDownloader) writeManifest(tableDir string, tableId string, items []*AttachmentManifestItem) error {
+	manifest := &AttachmentManifest{TableId: tableId, Items: []*AttachmentManifestItem{}}
+	for _, item := range items {
+		if item.Err == nil {
+			manifest.Items = append(manifest.Items, item)
+		}
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tableDir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(tableDir, attachmentManifestName), data, 0644)
+}
+
+// 获取数据表中全部附件类型字段的字段名
+func (d *AttachmentDownloader) attachmentFieldNames(ctx context.Context, tableId string) ([]string, error) {
+	iterator, err := d.service.AppTableField.ListByIterator(ctx, NewListAppTableFieldReqBuilder().
+		TableId(tableId).
+		Build(), d.options...)
+	if err != nil {
+		return nil, err
+	}
+	var fieldNames []string
+	for {
+		hasMore, field, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return fieldNames, nil
+		}
+		if field.Type != nil && *field.Type == TypeAttachment && field.FieldName != nil {
+			fieldNames = append(fieldNames, *field.FieldName)
+		}
+	}
+}
+
+// 遍历记录，收集待下载的附件
+func (d *AttachmentDownloader) collect(ctx context.Context, tableId string, fieldNames []string) ([]*AttachmentManifestItem, error) {
+	if len(fieldNames) == 0 {
+		return nil, nil
+	}
+	iterator, err := d.service.AppTableRecord.ListByIterator(ctx, NewListAppTableRecordReqBuilder().
+		TableId(tableId).
+		Build(), d.options...)
+	if err != nil {
+		return nil, err
+	}
+	var items []*AttachmentManifestItem
+	for {
+		hasMore, record, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return items, nil
+		}
+		if record.RecordId == nil {
+			continue
+		}
+		for _, fieldName := range fieldNames {
+			attachments, err := parseAttachments(record.Fields[fieldName])
+			if err != nil {
+				return nil, err
+			}
+			used := map[string]bool{}
+			for _, attachment := range attachments {
+				if attachment.FileToken == nil {
+					continue
+				}
+				item := &AttachmentManifestItem{RecordId: *record.RecordId, FieldName: fieldName, FileToken: *attachment.FileToken}
+				if attachment.Name != nil {
+					item.Name = *attachment.Name
+				}
+				if attachment.Size != nil {
+					item.Size = int64(*attachment.Size)
+				}
+				// 同一单元格中的同名附件以 file_token 区分
+				fileName := sanitizeFileName(item.Name)
+				if fileName == "" || used[fileName] {
+					fileName = item.FileToken + "_" + fileName
+				}
+				used[fileName] = true
+				item.Path = filepath.Join(sanitizeFileName(item.RecordId), sanitizeFileName(fieldName), fileName)
+				items = append(items, item)
+			}
+		}
+	}
+}
+
+// 下载单个附件，先写入临时文件，校验通过后再移动到目标路径
+func (d *AttachmentDownloader) download(ctx context.Context, tableDir string, item *AttachmentManifestItem) error {
+	resp, err := d.service.drive().Media.Download(ctx, larkdrive.NewDownloadMediaReqBuilder().
+		FileToken(item.FileToken).
+		Build(), d.options...)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return resp.CodeError
+	}
+
+	path := filepath.Join(tableDir, item.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".download"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), resp.File)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if item.Size > 0 && size != item.Size {
+		os.Remove(tmp)
+		return fmt.Errorf("attachment %s size mismatch, expected %d, got %d", item.FileToken, item.Size, size)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	item.Size = size
+	item.Sha256 = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+// 读取上次导出的清单，key 为文件相对路径；清单不存在或无法解析时返回空
+func (d *AttachmentDownloader) readManifest(tableDir string) map[string]*AttachmentManifestItem {
+	items := map[string]*AttachmentManifestItem{}
+	data, err := ioutil.ReadFile(filepath.Join(tableDir, attachmentManifestName))
+	if err != nil {
+		return items
+	}
+	manifest := &AttachmentManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return items
+	}
+	for _, item := range manifest.Items {
+		items[item.Path] = item
+	}
+	return items
+}
+
+// 校验本地文件的大小和 sha256
+func verifyFile(path string, size int64, sha string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	hash := sha256.New()
+	n, err := io.Copy(hash, file)
+	if err != nil {
+		return false
+	}
+	return n == size && hex.EncodeToString(hash.Sum(nil)) == sha
+}
+
+// 去掉文件名中的路径分隔符等不能用作文件名的字符
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', 0:
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
@@ -0,0 +1,118 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+	"github.com/larksuite/base-sdk-go/v3/service/drive/v1"
+)
+
+// 上传本地文件并追加到记录的附件字段
+//
+// - 文件上传到当前多维表格（Config 中的 appToken）下，按大小自动选择一次上传或分片上传
+//
+// - 字段中原有的附件会保留，新文件追加在其后
+//
+// - extra 用于开启了高级权限的多维表格，普通上传传空字符串即可
+//
+// - 返回更新后该字段的全部附件
+func (a *appTableRecord) AttachFiles(ctx context.Context, tableId string, recordId string, fieldName string, files []string, extra string, options ...larkcore.RequestOptionFunc) ([]*Attachment, error) {
+	return a.attachFiles(ctx, tableId, recordId, fieldName, files, extra, false, options...)
+}
+
+// 上传本地文件并替换记录附件字段中的全部附件
+//
+// - 与 AttachFiles 相同，但不保留字段中原有的附件
+func (a *appTableRecord) ReplaceFiles(ctx context.Context, tableId string, recordId string, fieldName string, files []string, extra string, options ...larkcore.RequestOptionFunc) ([]*Attachment, error) {
+	return a.attachFiles(ctx, tableId, recordId, fieldName, files, extra, true, options...)
+}
+
+func (a *appTableRecord) attachFiles(ctx context.Context, tableId string, recordId string, fieldName string, files []string, extra string, replace bool, options ...larkcore.RequestOptionFunc) ([]*Attachment, error) {
+	appToken := a.service.config.AppToken
+	if appToken == "" {
+		return nil, errors.New("app token is empty, attaching files requires the client to be created with an app token")
+	}
+
+	var attachments []*Attachment
+	if !replace {
+		getResp, err := a.Get(ctx, NewGetAppTableRecordReqBuilder().
+			TableId(tableId).
+			RecordId(recordId).
+			Build(), options...)
+		if err != nil {
+			return nil, err
+		}
+		if !getResp.Success() {
+			return nil, getResp.CodeError
+		}
+		if getResp.Data != nil && getResp.Data.Record != nil {
+			attachments, err = parseAttachments(getResp.Data.Record.Fields[fieldName])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, file := range files {
+		fileToken, err := a.service.drive().Media.UploadFile(ctx, file, larkdrive.ParentTypeUploadAllMediaBitableFile, appToken, extra, options...)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, NewAttachmentBuilder().FileToken(fileToken).Build())
+	}
+
+	cells := make([]map[string]interface{}, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment.FileToken == nil {
+			continue
+		}
+		cells = append(cells, map[string]interface{}{attachmentKeyFileToken: *attachment.FileToken})
+	}
+	updateResp, err := a.Update(ctx, NewUpdateAppTableRecordReqBuilder().
+		TableId(tableId).
+		RecordId(recordId).
+		AppTableRecord(NewAppTableRecordBuilder().
+			Fields(map[string]interface{}{fieldName: cells}).
+			Build()).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	if !updateResp.Success() {
+		return nil, updateResp.CodeError
+	}
+	if updateResp.Data == nil || updateResp.Data.Record == nil {
+		return attachments, nil
+	}
+	return parseAttachments(updateResp.Data.Record.Fields[fieldName])
+}
+
+// 将附件字段的值解析为附件列表，空值返回 nil
+func parseAttachments(value interface{}) ([]*Attachment, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var attachments []*Attachment
+	if err := json.Unmarshal(data, &attachments); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+	"github.com/larksuite/base-sdk-go/v3/service/drive/v1"
+)
+
+// 附件单元格中的字段名
+const (
+	attachmentKeyFileToken = "file_token"
+	attachmentKeyUrl       = "url"
+	attachmentKeyTmpUrl    = "tmp_url"
+)
+
+// 复用 BaseService 的配置访问云文档接口
+func (b *BaseService) drive() *larkdrive.DriveService {
+	return larkdrive.NewService(b.config)
+}
+
+// 为记录中的全部附件刷新临时下载链接
+//
+// - 遍历每条记录的每个附件单元格，批量获取临时下载链接后写回附件的 tmp_url
+//
+// - extra 用于开启了高级权限的多维表格，格式参考素材临时下载链接接口的 extra 说明，普通多维表格传空字符串即可
+//
+// - 未能获取到链接的附件保持原样
+func (a *appTableRecord) RefreshAttachmentUrls(ctx context.Context, records []*AppTableRecord, extra string, options ...larkcore.RequestOptionFunc) error {
+	var cells []map[string]interface{}
+	var fileTokens []string
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		for _, value := range record.Fields {
+			for _, cell := range attachmentCells(value) {
+				cells = append(cells, cell)
+				fileTokens = append(fileTokens, cell[attachmentKeyFileToken].(string))
+			}
+		}
+	}
+	if len(fileTokens) == 0 {
+		return nil
+	}
+
+	urls, err := a.service.drive().Media.BatchGetTmpDownloadUrls(ctx, fileTokens, extra, options...)
+	if err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		if url, ok := urls[cell[attachmentKeyFileToken].(string)]; ok {
+			cell[attachmentKeyTmpUrl] = url
+		}
+	}
+	return nil
+}
+
+// 从字段值中找出附件单元格，即包含 file_token 且带有下载链接的对象
+func attachmentCells(value interface{}) []map[string]interface{} {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	var cells []map[string]interface{}
+	for _, item := range items {
+		cell, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if fileToken, ok := cell[attachmentKeyFileToken].(string); !ok || fileToken == "" {
+			continue
+		}
+		_, hasUrl := cell[attachmentKeyUrl]
+		_, hasTmpUrl := cell[attachmentKeyTmpUrl]
+		if hasUrl || hasTmpUrl {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
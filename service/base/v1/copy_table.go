@@ -0,0 +1,729 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	CopyLinkDrop = "drop" // 不复制指向复制范围之外的关联字段
+	CopyLinkText = "text" // 转为多行文本字段，内容为关联记录索引列的值
+	CopyLinkKeep = "keep" // 保留关联，未映射的数据表ID和记录ID原样保留，仅在目标为同一多维表格时有效
+)
+
+const copyTableBatchLimit = 500
+
+// CopyTableOptions 复制数据表的选项
+type CopyTableOptions struct {
+	Name         string            // 目标数据表名称，默认与源数据表相同
+	CopyRecords  bool              // 是否复制记录
+	LinkStrategy string            // 指向复制范围之外的关联字段的处理方式，取值见 CopyLink* 常量，默认为 drop
+	TableIdMap   map[string]string // 已复制的数据表，源数据表ID到目标数据表ID，指向这些表的关联字段视为在复制范围之内
+	RecordIdMap  map[string]string // 已复制的记录，源记录ID到目标记录ID，用于重新映射关联字段的值
+}
+
+// CopyTableResult 复制数据表的结果
+//
+// - 依次复制多张数据表时，将结果中的 TableId、RecordIds 合并到下一次调用的 TableIdMap、RecordIdMap
+type CopyTableResult struct {
+	TableId   string            // 目标数据表ID
+	FieldIds  map[string]string // 源字段ID到目标字段ID
+	ViewIds   map[string]string // 源视图ID到目标视图ID
+	RecordIds map[string]string // 源记录ID到目标记录ID
+	Notes     []string          // 未能复制的字段、字段值和筛选条件的说明
+}
+
+const (
+	copyFieldPlain      = iota // 原样复制
+	copyFieldSelfLink          // 关联到数据表自身
+	copyFieldMappedLink        // 关联到 TableIdMap 中已复制的数据表
+	copyFieldKeepLink          // 按 CopyLinkKeep 保留的关联
+	copyFieldLinkText          // 按 CopyLinkText 转为文本的关联
+	copyFieldBackLink          // 自身双向关联的反向字段，由正向字段自动生成
+	copyFieldDropped           // 不复制
+)
+
+type tableCopier struct {
+	src       *BaseService
+	dst       *BaseService
+	tableId   string
+	opts      *CopyTableOptions
+	options   []larkcore.RequestOptionFunc
+	result    *CopyTableResult
+	fields    []*AppTableField
+	kinds     map[string]int
+	optionIds map[string]map[string]string
+	linkTexts map[string]map[string]string
+}
+
+// 将数据表复制到另一个多维表格
+//
+// - 当前服务所属的多维表格为源，dst 为目标多维表格的服务，两者可以是同一个
+//
+// - 依次重建字段（包括单选多选选项、公式、自动编号设置）、记录和视图，视图的筛选条件、隐藏字段和层级结构会映射到新的字段ID
+//
+// - 附件字段的值不会被复制；公式、创建时间等只读字段的值由目标表自动生成
+func (b *BaseService) CopyTable(ctx context.Context, tableId string, dst *BaseService, opts *CopyTableOptions, options ...larkcore.RequestOptionFunc) (*CopyTableResult, error) {
+	// 复制一份选项，补全的默认值不写回调用方
+	copied := CopyTableOptions{}
+	if opts != nil {
+		copied = *opts
+	}
+	opts = &copied
+	switch opts.LinkStrategy {
+	case "":
+		opts.LinkStrategy = CopyLinkDrop
+	case CopyLinkDrop, CopyLinkText, CopyLinkKeep:
+	default:
+		return nil, fmt.Errorf("invalid link strategy %q", opts.LinkStrategy)
+	}
+	c := &tableCopier{
+		src:       b,
+		dst:       dst,
+		tableId:   tableId,
+		opts:      opts,
+		options:   options,
+		result:    &CopyTableResult{FieldIds: map[string]string{}, ViewIds: map[string]string{}, RecordIds: map[string]string{}},
+		kinds:     map[string]int{},
+		optionIds: map[string]map[string]string{},
+		linkTexts: map[string]map[string]string{},
+	}
+
+	fields, err := b.AppTableField.ListAll(ctx, tableId, options...)
+	if err != nil {
+		return nil, err
+	}
+	views, err := b.AppTableView.ListAll(ctx, tableId, options...)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Name == "" {
+		tables, err := b.AppTable.ListAll(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			if table.TableId != nil && *table.TableId == tableId && table.Name != nil {
+				opts.Name = *table.Name
+			}
+		}
+		if opts.Name == "" {
+			return nil, fmt.Errorf("table %s not found", tableId)
+		}
+	}
+	c.fields = fields
+	c.plan()
+
+	defaultViewId, err := c.createFields(ctx, views)
+	if err != nil {
+		return c.result, err
+	}
+	if opts.CopyRecords {
+		if err := c.copyRecords(ctx); err != nil {
+			return c.result, err
+		}
+	}
+	return c.result, c.copyViews(ctx, views, defaultViewId)
+}
+
+func (c *tableCopier) note(format string, args ...interface{}) {
+	c.result.Notes = append(c.result.Notes, fmt.Sprintf(format, args...))
+}
+
+// 决定每个字段的复制方式
+func (c *tableCopier) plan() {
+	backNames := map[string]bool{}
+	for _, field := range c.fields {
+		if field.FieldId == nil || field.Type == nil {
+			continue
+		}
+		id, name := *field.FieldId, stringValue(field.FieldName)
+		if *field.Type != TypeLink && *field.Type != TypeDuplexLink {
+			c.kinds[id] = copyFieldPlain
+			continue
+		}
+		target := ""
+		if field.Property != nil {
+			target = stringValue(field.Property.TableId)
+		}
+		if target == "" {
+			c.kinds[id] = copyFieldDropped
+			c.note("link field %s has no target table, dropped", name)
+			continue
+		}
+		if _, mapped := c.opts.TableIdMap[target]; target == c.tableId || mapped {
+			if backNames[name] {
+				c.kinds[id] = copyFieldBackLink
+				continue
+			}
+			if target == c.tableId {
+				c.kinds[id] = copyFieldSelfLink
+				if *field.Type == TypeDuplexLink && field.Property.BackFieldName != nil {
+					backNames[*field.Property.BackFieldName] = true
+				}
+			} else {
+				c.kinds[id] = copyFieldMappedLink
+			}
+			continue
+		}
+		switch c.opts.LinkStrategy {
+		case CopyLinkText:
+			c.kinds[id] = copyFieldLinkText
+		case CopyLinkKeep:
+			c.kinds[id] = copyFieldKeepLink
+		default:
+			c.kinds[id] = copyFieldDropped
+			c.note("link field %s to table %s dropped", name, target)
+		}
+	}
+}
+
+// 创建数据表和字段，返回默认视图ID
+func (c *tableCopier) createFields(ctx context.Context, views []*AppTableView) (string, error) {
+	var primary *AppTableField
+	for _, field := range c.fields {
+		if field.IsPrimary != nil && *field.IsPrimary {
+			primary = field
+		}
+	}
+	if primary == nil {
+		return "", fmt.Errorf("table %s has no primary field", c.tableId)
+	}
+	header := c.fieldDefinition(primary)
+	table := NewReqTableBuilder().
+		Name(c.opts.Name).
+		Fields([]*AppTableCreateHeader{NewAppTableCreateHeaderBuilder().
+			FieldName(*header.FieldName).
+			Type(*header.Type).
+			Property(header.Property).
+			Description(header.Description).
+			Build()})
+	if len(views) > 0 && views[0].ViewName != nil {
+		table.DefaultViewName(*views[0].ViewName)
+	}
+	resp, err := c.dst.AppTable.Create(ctx, NewCreateAppTableReqBuilder().
+		Body(NewCreateAppTableReqBodyBuilder().Table(table.Build()).Build()).
+		Build(), c.options...)
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success() {
+		return "", resp.CodeError
+	}
+	if resp.Data == nil || resp.Data.TableId == nil {
+		return "", fmt.Errorf("create table %s returned no table id", c.opts.Name)
+	}
+	c.result.TableId = *resp.Data.TableId
+	if len(resp.Data.FieldIdList) > 0 {
+		c.result.FieldIds[*primary.FieldId] = resp.Data.FieldIdList[0]
+	}
+
+	// 公式可能引用任意字段，最后创建，公式之间按引用关系排序
+	var formulas []*AppTableField
+	for _, field := range c.fields {
+		if field == primary || field.FieldId == nil {
+			continue
+		}
+		switch c.kinds[*field.FieldId] {
+		case copyFieldBackLink, copyFieldDropped:
+			continue
+		}
+		if *field.Type == TypeFormula {
+			formulas = append(formulas, field)
+			continue
+		}
+		if err := c.createField(ctx, field); err != nil {
+			return "", err
+		}
+	}
+	for _, field := range c.orderFormulas(formulas) {
+		if err := c.createField(ctx, field); err != nil {
+			return "", err
+		}
+	}
+
+	// 反向关联字段由目标表自动生成，选项ID也需要按名称对应
+	created, err := c.dst.AppTableField.ListAll(ctx, c.result.TableId, c.options...)
+	if err != nil {
+		return "", err
+	}
+	byName := map[string]*AppTableField{}
+	for _, field := range created {
+		byName[stringValue(field.FieldName)] = field
+	}
+	for _, field := range c.fields {
+		if field.FieldId == nil || c.kinds[*field.FieldId] == copyFieldDropped {
+			continue
+		}
+		target, ok := byName[stringValue(field.FieldName)]
+		if !ok || target.FieldId == nil {
+			continue
+		}
+		c.result.FieldIds[*field.FieldId] = *target.FieldId
+		if field.Property == nil || target.Property == nil || len(field.Property.Options) == 0 {
+			continue
+		}
+		options := map[string]string{}
+		for _, option := range field.Property.Options {
+			if option.Id == nil {
+				continue
+			}
+			if id, err := fieldOptionId(target, stringValue(option.Name)); err == nil {
+				options[*option.Id] = id
+			}
+		}
+		c.optionIds[*field.FieldId] = options
+	}
+	return stringValue(resp.Data.DefaultViewId), nil
+}
+
+// 公式之间按引用关系排序，被引用的公式先创建，这样引用它的公式才能替换为目标字段ID
+func (c *tableCopier) orderFormulas(formulas []*AppTableField) []*AppTableField {
+	ordered := make([]*AppTableField, 0, len(formulas))
+	pending := append([]*AppTableField{}, formulas...)
+	for len(pending) > 0 {
+		var rest []*AppTableField
+		for _, field := range pending {
+			if formulaReferences(field, pending) {
+				rest = append(rest, field)
+			} else {
+				ordered = append(ordered, field)
+			}
+		}
+		if len(rest) == len(pending) {
+			// 循环引用无法排序，按原顺序创建，未创建的公式字段ID保持原样
+			for _, field := range rest {
+				c.note("formula field %s has circular references, some references may not be remapped", stringValue(field.FieldName))
+			}
+			return append(ordered, rest...)
+		}
+		pending = rest
+	}
+	return ordered
+}
+
+// 公式是否引用了 others 中除自身以外的字段
+func formulaReferences(field *AppTableField, others []*AppTableField) bool {
+	if field.Property == nil || field.Property.FormulaExpression == nil {
+		return false
+	}
+	for _, other := range others {
+		if other != field && strings.Contains(*field.Property.FormulaExpression, *other.FieldId) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *tableCopier) createField(ctx context.Context, field *AppTableField) error {
+	resp, err := c.dst.AppTableField.Create(ctx, NewCreateAppTableFieldReqBuilder().
+		TableId(c.result.TableId).
+		AppTableField(c.fieldDefinition(field)).
+		Build(), c.options...)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return fmt.Errorf("create field %s: %w", stringValue(field.FieldName), resp.CodeError)
+	}
+	if resp.Data == nil {
+		return fmt.Errorf("create field %s returned no data", stringValue(field.FieldName))
+	}
+	if resp.Data.Field != nil && resp.Data.Field.FieldId != nil {
+		c.result.FieldIds[*field.FieldId] = *resp.Data.Field.FieldId
+	}
+	return nil
+}
+
+// 生成目标表中的字段定义
+func (c *tableCopier) fieldDefinition(field *AppTableField) *AppTableField {
+	definition := &AppTableField{
+		FieldName:   field.FieldName,
+		Type:        field.Type,
+		Description: field.Description,
+		UiType:      field.UiType,
+	}
+	if field.Property != nil {
+		property := &AppTableFieldProperty{}
+		data, _ := json.Marshal(field.Property)
+		_ = json.Unmarshal(data, property)
+		property.TableName = nil
+		for _, option := range property.Options {
+			option.Id = nil
+		}
+		definition.Property = property
+	}
+
+	switch c.kinds[*field.FieldId] {
+	case copyFieldSelfLink:
+		definition.Property.TableId = &c.result.TableId
+	case copyFieldMappedLink, copyFieldKeepLink:
+		if target, ok := c.opts.TableIdMap[*definition.Property.TableId]; ok {
+			definition.Property.TableId = &target
+		}
+	case copyFieldLinkText:
+		fieldType := TypeText
+		definition.Type = &fieldType
+		definition.Property = nil
+		definition.UiType = nil
+	}
+	if *definition.Type == TypeFormula && definition.Property != nil && definition.Property.FormulaExpression != nil {
+		expression := c.replacer().Replace(*definition.Property.FormulaExpression)
+		definition.Property.FormulaExpression = &expression
+	}
+	return definition
+}
+
+// 将公式中引用的数据表ID、字段ID替换为目标表中的ID
+func (c *tableCopier) replacer() *strings.Replacer {
+	pairs := []string{c.tableId, c.result.TableId}
+	for from, to := range c.opts.TableIdMap {
+		pairs = append(pairs, from, to)
+	}
+	for from, to := range c.result.FieldIds {
+		pairs = append(pairs, from, to)
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+func (c *tableCopier) copyRecords(ctx context.Context) error {
+	records, err := c.src.AppTableRecord.ListAll(ctx, c.tableId, c.options...)
+	if err != nil {
+		return err
+	}
+
+	attachments := map[string]bool{}
+	creates := make([]*AppTableRecord, 0, len(records))
+	for _, record := range records {
+		fields := map[string]interface{}{}
+		for _, field := range c.fields {
+			name := stringValue(field.FieldName)
+			value, ok := record.Fields[name]
+			if !ok || value == nil || field.FieldId == nil {
+				continue
+			}
+			switch c.kinds[*field.FieldId] {
+			case copyFieldPlain:
+				if *field.Type == TypeAttachment {
+					attachments[name] = true
+					continue
+				}
+				if cell, ok := copyCellValue(*field.Type, value); ok {
+					fields[name] = cell
+				}
+			case copyFieldMappedLink, copyFieldKeepLink:
+				if ids := c.mapRecordIds(mirrorLinkRecordIds(value), c.opts.RecordIdMap, c.kinds[*field.FieldId] == copyFieldKeepLink); len(ids) > 0 {
+					fields[name] = ids
+				}
+			case copyFieldLinkText:
+				texts, err := c.linkText(ctx, field, mirrorLinkRecordIds(value))
+				if err != nil {
+					return err
+				}
+				if texts != "" {
+					fields[name] = texts
+				}
+			}
+		}
+		creates = append(creates, NewAppTableRecordBuilder().Fields(fields).Build())
+	}
+	for name := range attachments {
+		c.note("attachments in field %s not copied", name)
+	}
+
+	for start := 0; start < len(creates); start += copyTableBatchLimit {
+		end := start + copyTableBatchLimit
+		if end > len(creates) {
+			end = len(creates)
+		}
+		resp, err := c.dst.AppTableRecord.BatchCreate(ctx, NewBatchCreateAppTableRecordReqBuilder().
+			TableId(c.result.TableId).
+			Body(NewBatchCreateAppTableRecordReqBodyBuilder().Records(creates[start:end]).Build()).
+			Build(), c.options...)
+		if err != nil {
+			return err
+		}
+		if !resp.Success() {
+			return resp.CodeError
+		}
+		if resp.Data == nil {
+			return fmt.Errorf("create records in table %s returned no data", c.result.TableId)
+		}
+		for i, created := range resp.Data.Records {
+			if start+i < len(records) && records[start+i].RecordId != nil && created.RecordId != nil {
+				c.result.RecordIds[*records[start+i].RecordId] = *created.RecordId
+			}
+		}
+	}
+
+	// 关联到自身的字段需要在全部记录创建后再写入
+	var updates []*AppTableRecord
+	for _, record := range records {
+		fields := map[string]interface{}{}
+		for _, field := range c.fields {
+			if field.FieldId == nil || c.kinds[*field.FieldId] != copyFieldSelfLink {
+				continue
+			}
+			name := stringValue(field.FieldName)
+			if ids := c.mapRecordIds(mirrorLinkRecordIds(record.Fields[name]), c.result.RecordIds, false); len(ids) > 0 {
+				fields[name] = ids
+			}
+		}
+		if recordId, ok := c.result.RecordIds[stringValue(record.RecordId)]; ok && len(fields) > 0 {
+			updates = append(updates, NewAppTableRecordBuilder().RecordId(recordId).Fields(fields).Build())
+		}
+	}
+	for start := 0; start < len(updates); start += copyTableBatchLimit {
+		end := start + copyTableBatchLimit
+		if end > len(updates) {
+			end = len(updates)
+		}
+		resp, err := c.dst.AppTableRecord.BatchUpdate(ctx, NewBatchUpdateAppTableRecordReqBuilder().
+			TableId(c.result.TableId).
+			Body(NewBatchUpdateAppTableRecordReqBodyBuilder().Records(updates[start:end]).Build()).
+			Build(), c.options...)
+		if err != nil {
+			return err
+		}
+		if !resp.Success() {
+			return resp.CodeError
+		}
+	}
+	return nil
+}
+
+func (c *tableCopier) mapRecordIds(recordIds []string, mapping map[string]string, keep bool) []string {
+	ids := make([]string, 0, len(recordIds))
+	for _, id := range recordIds {
+		if target, ok := mapping[id]; ok {
+			ids = append(ids, target)
+		} else if keep {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// 取关联记录索引列的值，以逗号连接
+func (c *tableCopier) linkText(ctx context.Context, field *AppTableField, recordIds []string) (string, error) {
+	tableId := stringValue(field.Property.TableId)
+	texts, ok := c.linkTexts[tableId]
+	if !ok {
+		fields, err := c.src.AppTableField.ListAll(ctx, tableId, c.options...)
+		if err != nil {
+			return "", err
+		}
+		primary := ""
+		for _, f := range fields {
+			if f.IsPrimary != nil && *f.IsPrimary {
+				primary = stringValue(f.FieldName)
+			}
+		}
+		records, err := c.src.AppTableRecord.ListAll(ctx, tableId, c.options...)
+		if err != nil {
+			return "", err
+		}
+		texts = map[string]string{}
+		for _, record := range records {
+			texts[stringValue(record.RecordId)] = strings.Join(mirrorTexts(record.Fields[primary]), "")
+		}
+		c.linkTexts[tableId] = texts
+	}
+	values := make([]string, 0, len(recordIds))
+	for _, id := range recordIds {
+		if text := texts[id]; text != "" {
+			values = append(values, text)
+		}
+	}
+	return strings.Join(values, ", "), nil
+}
+
+// 将读取到的单元格值转换为写入格式，只读字段返回 false
+func copyCellValue(fieldType int, value interface{}) (interface{}, bool) {
+	switch fieldType {
+	case TypeNumber, TypeSingleSelect, TypeMultiSelect, TypeDateTime, TypeCheckbox, TypePhoneNumber, TypeUrl:
+		return value, true
+	case TypeText:
+		if s, ok := value.(string); ok {
+			return s, true
+		}
+		return strings.Join(mirrorTexts(value), ""), true
+	case TypeLocation:
+		if m, ok := value.(map[string]interface{}); ok {
+			return m["location"], m["location"] != nil
+		}
+		return value, true
+	case TypeUser, TypeGroupChat:
+		list, _ := value.([]interface{})
+		cells := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if m, ok := item.(map[string]interface{}); ok && m["id"] != nil {
+				cells = append(cells, map[string]interface{}{"id": m["id"]})
+			}
+		}
+		return cells, len(cells) > 0
+	}
+	return nil, false
+}
+
+func (c *tableCopier) copyViews(ctx context.Context, views []*AppTableView, defaultViewId string) error {
+	for i, view := range views {
+		if view.ViewId == nil {
+			continue
+		}
+		viewId := ""
+		if i == 0 && defaultViewId != "" {
+			viewId = defaultViewId
+		} else {
+			reqView := NewReqViewBuilder().ViewName(stringValue(view.ViewName))
+			if view.ViewType != nil {
+				reqView.ViewType(*view.ViewType)
+			}
+			resp, err := c.dst.AppTableView.Create(ctx, NewCreateAppTableViewReqBuilder().
+				TableId(c.result.TableId).
+				ReqView(reqView.Build()).
+				Build(), c.options...)
+			if err != nil {
+				return err
+			}
+			if !resp.Success() {
+				return fmt.Errorf("create view %s: %w", stringValue(view.ViewName), resp.CodeError)
+			}
+			if resp.Data == nil || resp.Data.View == nil || resp.Data.View.ViewId == nil {
+				continue
+			}
+			viewId = *resp.Data.View.ViewId
+		}
+		c.result.ViewIds[*view.ViewId] = viewId
+
+		property := c.viewProperty(view)
+		if property == nil {
+			continue
+		}
+		resp, err := c.dst.AppTableView.Patch(ctx, NewPatchAppTableViewReqBuilder().
+			TableId(c.result.TableId).
+			ViewId(viewId).
+			Body(NewPatchAppTableViewReqBodyBuilder().Property(property).Build()).
+			Build(), c.options...)
+		if err != nil {
+			return err
+		}
+		if !resp.Success() {
+			return fmt.Errorf("update view %s: %w", stringValue(view.ViewName), resp.CodeError)
+		}
+	}
+	return nil
+}
+
+// 将视图属性中的字段ID、选项ID和记录ID映射到目标表
+func (c *tableCopier) viewProperty(view *AppTableView) *AppTableViewProperty {
+	source := view.Property
+	if source == nil {
+		return nil
+	}
+	fields := map[string]*AppTableField{}
+	for _, field := range c.fields {
+		fields[stringValue(field.FieldId)] = field
+	}
+	property := &AppTableViewProperty{}
+	empty := true
+
+	if source.FilterInfo != nil && len(source.FilterInfo.Conditions) > 0 {
+		var conditions []*AppTableViewPropertyFilterInfoCondition
+		for _, condition := range source.FilterInfo.Conditions {
+			fieldId := stringValue(condition.FieldId)
+			target, ok := c.result.FieldIds[fieldId]
+			kind := c.kinds[fieldId]
+			// 转为文本的关联字段只保留不带筛选值的条件
+			if !ok || (kind == copyFieldLinkText && condition.Value != nil) {
+				name := fieldId
+				if field := fields[fieldId]; field != nil {
+					name = stringValue(field.FieldName)
+				}
+				c.note("filter on field %s in view %s dropped", name, stringValue(view.ViewName))
+				continue
+			}
+			remapped := &AppTableViewPropertyFilterInfoCondition{
+				FieldId:   &target,
+				Operator:  condition.Operator,
+				FieldType: condition.FieldType,
+				Value:     condition.Value,
+			}
+			if kind == copyFieldLinkText {
+				fieldType := strconv.Itoa(TypeText)
+				remapped.FieldType = &fieldType
+			}
+			if condition.Value != nil {
+				var mapping map[string]string
+				switch kind {
+				case copyFieldSelfLink:
+					mapping = c.result.RecordIds
+				case copyFieldMappedLink, copyFieldKeepLink:
+					mapping = c.opts.RecordIdMap
+				default:
+					mapping = c.optionIds[fieldId]
+				}
+				remapped.Value = copyFilterValue(*condition.Value, mapping)
+			}
+			conditions = append(conditions, remapped)
+		}
+		if len(conditions) > 0 {
+			property.FilterInfo = &AppTableViewPropertyFilterInfo{
+				Conjunction: source.FilterInfo.Conjunction,
+				Conditions:  conditions,
+			}
+			empty = false
+		}
+	}
+	if len(source.HiddenFields) > 0 {
+		for _, fieldId := range source.HiddenFields {
+			if target, ok := c.result.FieldIds[fieldId]; ok {
+				property.HiddenFields = append(property.HiddenFields, target)
+			}
+		}
+		empty = empty && len(property.HiddenFields) == 0
+	}
+	if source.HierarchyConfig != nil && source.HierarchyConfig.FieldId != nil {
+		if target, ok := c.result.FieldIds[*source.HierarchyConfig.FieldId]; ok && c.kinds[*source.HierarchyConfig.FieldId] == copyFieldSelfLink {
+			property.HierarchyConfig = &AppTableViewPropertyHierarchyConfig{FieldId: &target}
+			empty = false
+		}
+	}
+	if empty {
+		return nil
+	}
+	return property
+}
+
+// 替换筛选值中的选项ID或记录ID，值不是字符串数组时原样返回
+func copyFilterValue(value string, mapping map[string]string) *string {
+	var ids []string
+	if len(mapping) == 0 || json.Unmarshal([]byte(value), &ids) != nil {
+		return &value
+	}
+	for i, id := range ids {
+		if target, ok := mapping[id]; ok {
+			ids[i] = target
+		}
+	}
+	data, _ := json.Marshal(ids)
+	remapped := string(data)
+	return &remapped
+}
@@ -0,0 +1,599 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+	"github.com/larksuite/base-sdk-go/v3/service/drive/v1"
+)
+
+const (
+	formCSRFCookie    = "base_form_csrf"
+	formCSRFField     = "csrf_token"
+	formInputPrefix   = "f_"
+	formSchemaTTL     = time.Minute
+	formMaxUploadSize = 100 << 20
+)
+
+// FormTemplate FormHandler 默认使用的页面模板，可以此为基础修改后通过 FormHandler.Template 替换
+//
+// - 模板数据为 *FormPage
+const FormTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 640px; margin: 2em auto; padding: 0 1em; }
+.field { margin-bottom: 1.2em; }
+.field > label { display: block; font-weight: bold; margin-bottom: .3em; }
+.description { color: #666; margin: .2em 0; }
+.error { color: #c00; }
+input[type=text], input[type=number], input[type=date], input[type=url], input[type=tel], textarea, select { width: 100%; box-sizing: border-box; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+{{if .Submitted}}<p>Submitted, thank you.</p>
+{{else}}{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+<form method="post" enctype="multipart/form-data">
+<input type="hidden" name="{{.CSRFField}}" value="{{.CSRFToken}}">
+{{range .Inputs}}<div class="field">
+<label for="{{.Name}}">{{.Title}}{{if .Required}} *{{end}}</label>
+{{if .Description}}<p class="description">{{.Description}}</p>{{end}}
+{{if eq .Kind "textarea"}}<textarea id="{{.Name}}" name="{{.Name}}" rows="4"{{if .Required}} required{{end}}>{{.Value}}</textarea>
+{{else if eq .Kind "select"}}<select id="{{.Name}}" name="{{.Name}}"{{if .Required}} required{{end}}><option value=""></option>{{range .Options}}<option value="{{.Name}}"{{if .Selected}} selected{{end}}>{{.Name}}</option>{{end}}</select>
+{{else if eq .Kind "checkboxes"}}{{$name := .Name}}{{range .Options}}<label><input type="checkbox" name="{{$name}}" value="{{.Name}}"{{if .Selected}} checked{{end}}> {{.Name}}</label> {{end}}
+{{else if eq .Kind "checkbox"}}<input type="checkbox" id="{{.Name}}" name="{{.Name}}" value="true"{{if .Value}} checked{{end}}>
+{{else if eq .Kind "file"}}<input type="file" id="{{.Name}}" name="{{.Name}}" multiple{{if .Required}} required{{end}}>
+{{else}}<input type="{{.Kind}}" id="{{.Name}}" name="{{.Name}}" value="{{.Value}}"{{if eq .Kind "number"}} step="any"{{if .Min}} min="{{.Min}}"{{end}}{{if .Max}} max="{{.Max}}"{{end}}{{end}}{{if .Required}} required{{end}}>
+{{end}}{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+</div>
+{{end}}<button type="submit">Submit</button>
+</form>
+{{end}}</body>
+</html>
+`
+
+var defaultFormTemplate = template.Must(template.New("form").Parse(FormTemplate))
+
+// FormPage 渲染表单页面的模板数据
+type FormPage struct {
+	Title       string       // 表单名称
+	Description string       // 表单描述
+	Inputs      []*FormInput // 按表单顺序排列的可见问题
+	CSRFField   string       // CSRF token 的表单字段名
+	CSRFToken   string       // CSRF token
+	Error       string       // 整体错误，例如提交失败
+	Submitted   bool         // 是否已成功提交
+	RecordId    string       // 提交成功后新记录的ID
+}
+
+// FormInput 表单中的一个问题
+type FormInput struct {
+	Name        string             // 输入项名称
+	Title       string             // 问题标题
+	Description string             // 问题描述
+	Required    bool               // 是否必填
+	Kind        string             // 输入类型：textarea、number、select、checkboxes、checkbox、date、url、tel、file
+	Options     []*FormInputOption // 单选、多选字段的选项
+	Value       string             // 提交失败时回填的值
+	Min         string             // 数字字段的最小值
+	Max         string             // 数字字段的最大值
+	Error       string             // 校验错误
+	field       *AppTableField
+}
+
+// FormInputOption 单选、多选字段的选项
+type FormInputOption struct {
+	Name     string
+	Selected bool
+}
+
+// FormHandler 以 HTML 表单的形式收集记录，适用于无法使用飞书表单的场景
+//
+// - GET 按表单问题的顺序、标题、描述和必填设置渲染页面，POST 在服务端按字段类型和必填校验后新增记录
+//
+// - 支持多行文本、数字、单选、多选、日期、复选框、超链接、电话号码和附件字段，其它类型的问题不会渲染
+//
+// - 附件上传到当前多维表格（Config 中的 appToken）下，需要使用 app token 创建客户端
+//
+// - 使用 cookie 与签名 token 双重校验防止 CSRF；多实例部署时需通过 CSRFSecret 设置相同的密钥
+type FormHandler struct {
+	service       *BaseService
+	tableId       string
+	formId        string
+	options       []larkcore.RequestOptionFunc
+	template      *template.Template
+	secret        []byte
+	maxUploadSize int64
+
+	mu       sync.Mutex
+	page     *FormPage
+	loadedAt time.Time
+}
+
+// 创建表单处理器，formId 为表单视图的 ID
+func (b *BaseService) NewFormHandler(tableId string, formId string, options ...larkcore.RequestOptionFunc) (*FormHandler, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("generate csrf secret: %w", err)
+	}
+	return &FormHandler{
+		service:       b,
+		tableId:       tableId,
+		formId:        formId,
+		options:       options,
+		template:      defaultFormTemplate,
+		secret:        secret,
+		maxUploadSize: formMaxUploadSize,
+	}, nil
+}
+
+// 替换页面模板，模板数据为 *FormPage
+func (h *FormHandler) Template(t *template.Template) *FormHandler {
+	h.template = t
+	return h
+}
+
+// 设置签名 CSRF token 的密钥，默认在创建时随机生成
+func (h *FormHandler) CSRFSecret(secret []byte) *FormHandler {
+	h.secret = secret
+	return h
+}
+
+// 单次提交的最大字节数，包括所有上传的文件，默认 100MB
+func (h *FormHandler) MaxUploadSize(size int64) *FormHandler {
+	h.maxUploadSize = size
+	return h
+}
+
+func (h *FormHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		page, err := h.newPage(r.Context())
+		if err != nil {
+			h.logError(r.Context(), "load form", err)
+			http.Error(w, "form is temporarily unavailable", http.StatusBadGateway)
+			return
+		}
+		token, err := h.csrfToken(w, r)
+		if err != nil {
+			h.logError(r.Context(), "generate csrf token", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		page.CSRFToken = token
+		h.render(r.Context(), w, http.StatusOK, page)
+	case http.MethodPost:
+		h.submit(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *FormHandler) submit(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		h.logError(r.Context(), "parse form", err)
+		http.Error(w, "invalid form submission", http.StatusBadRequest)
+		return
+	}
+	if r.MultipartForm != nil {
+		defer r.MultipartForm.RemoveAll()
+	}
+	if !h.validCSRF(r) {
+		http.Error(w, "invalid csrf token", http.StatusForbidden)
+		return
+	}
+	page, err := h.newPage(r.Context())
+	if err != nil {
+		h.logError(r.Context(), "load form", err)
+		http.Error(w, "form is temporarily unavailable", http.StatusBadGateway)
+		return
+	}
+	page.CSRFToken = r.PostFormValue(formCSRFField)
+
+	fields := map[string]interface{}{}
+	files := map[*FormInput][]*multipart.FileHeader{}
+	invalid := false
+	for _, input := range page.Inputs {
+		name := stringValue(input.field.FieldName)
+		if input.Kind == "file" {
+			if r.MultipartForm != nil {
+				files[input] = r.MultipartForm.File[input.Name]
+			}
+			if input.Required && len(files[input]) == 0 {
+				input.Error = "this field is required"
+				invalid = true
+			}
+			continue
+		}
+		values := r.PostForm[input.Name]
+		input.fill(values)
+		value, err := formFieldValue(input.field, values)
+		if err == nil && value == nil && input.Required {
+			err = errors.New("this field is required")
+		}
+		if err != nil {
+			input.Error = err.Error()
+			invalid = true
+			continue
+		}
+		if value != nil {
+			fields[name] = value
+		}
+	}
+	if invalid {
+		page.Error = "please correct the errors below"
+		h.render(r.Context(), w, http.StatusUnprocessableEntity, page)
+		return
+	}
+
+	for input, headers := range files {
+		if len(headers) == 0 {
+			continue
+		}
+		cells, err := h.upload(r.Context(), headers)
+		if err != nil {
+			h.logError(r.Context(), "upload attachments", err)
+			page.Error = "upload failed, please try again later"
+			h.render(r.Context(), w, http.StatusBadGateway, page)
+			return
+		}
+		fields[stringValue(input.field.FieldName)] = cells
+	}
+
+	resp, err := h.service.AppTableRecord.Create(r.Context(), NewCreateAppTableRecordReqBuilder().
+		TableId(h.tableId).
+		AppTableRecord(NewAppTableRecordBuilder().Fields(fields).Build()).
+		Build(), h.options...)
+	if err == nil && !resp.Success() {
+		err = resp.CodeError
+	}
+	if err != nil {
+		h.logError(r.Context(), "create record", err)
+		page.Error = "submit failed, please try again later"
+		h.render(r.Context(), w, http.StatusBadGateway, page)
+		return
+	}
+	page.Submitted = true
+	if resp.Data != nil && resp.Data.Record != nil {
+		page.RecordId = stringValue(resp.Data.Record.RecordId)
+	}
+	h.render(r.Context(), w, http.StatusOK, page)
+}
+
+// 状态码已写出，模板执行失败时只能记录日志
+func (h *FormHandler) render(ctx context.Context, w http.ResponseWriter, status int, page *FormPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if err := h.template.Execute(w, page); err != nil {
+		h.logError(ctx, "render form", err)
+	}
+}
+
+// 生成页面数据，表单与字段定义缓存一分钟
+func (h *FormHandler) newPage(ctx context.Context) (*FormPage, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.page == nil || time.Since(h.loadedAt) > formSchemaTTL {
+		page, err := h.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		h.page, h.loadedAt = page, time.Now()
+	}
+	page := *h.page
+	page.Inputs = make([]*FormInput, 0, len(h.page.Inputs))
+	for _, input := range h.page.Inputs {
+		copied := *input
+		copied.Options = make([]*FormInputOption, 0, len(input.Options))
+		for _, option := range input.Options {
+			copied.Options = append(copied.Options, &FormInputOption{Name: option.Name})
+		}
+		page.Inputs = append(page.Inputs, &copied)
+	}
+	return &page, nil
+}
+
+func (h *FormHandler) load(ctx context.Context) (*FormPage, error) {
+	resp, err := h.service.AppTableForm.Get(ctx, NewGetAppTableFormReqBuilder().
+		TableId(h.tableId).
+		FormId(h.formId).
+		Build(), h.options...)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, resp.CodeError
+	}
+	page := &FormPage{CSRFField: formCSRFField}
+	if resp.Data != nil && resp.Data.Form != nil {
+		page.Title = stringValue(resp.Data.Form.Name)
+		page.Description = stringValue(resp.Data.Form.Description)
+	}
+
+	fields, err := h.service.AppTableField.ListAll(ctx, h.tableId, h.options...)
+	if err != nil {
+		return nil, err
+	}
+	byId := map[string]*AppTableField{}
+	for _, field := range fields {
+		byId[stringValue(field.FieldId)] = field
+	}
+	questions, err := h.service.AppTableFormField.ListAll(ctx, h.tableId, h.formId, h.options...)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range questions {
+		field, ok := byId[stringValue(question.FieldId)]
+		if !ok || !boolValue(question.Visible) || field.Type == nil {
+			continue
+		}
+		kind := formInputKind(*field.Type)
+		if kind == "" {
+			continue
+		}
+		input := &FormInput{
+			Name:        formInputPrefix + *field.FieldId,
+			Title:       stringValue(question.Title),
+			Description: stringValue(question.Description),
+			Required:    boolValue(question.Required),
+			Kind:        kind,
+			field:       field,
+		}
+		if input.Title == "" {
+			input.Title = stringValue(field.FieldName)
+		}
+		if field.Property != nil {
+			for _, option := range field.Property.Options {
+				input.Options = append(input.Options, &FormInputOption{Name: stringValue(option.Name)})
+			}
+			if field.Property.Min != nil {
+				input.Min = strconv.FormatFloat(*field.Property.Min, 'f', -1, 64)
+			}
+			if field.Property.Max != nil {
+				input.Max = strconv.FormatFloat(*field.Property.Max, 'f', -1, 64)
+			}
+		}
+		page.Inputs = append(page.Inputs, input)
+	}
+	return page, nil
+}
+
+// 回填提交的值，校验失败时重新渲染
+func (input *FormInput) fill(values []string) {
+	selected := map[string]bool{}
+	for _, value := range values {
+		selected[value] = true
+	}
+	for _, option := range input.Options {
+		option.Selected = selected[option.Name]
+	}
+	if len(values) > 0 {
+		input.Value = values[0]
+	}
+}
+
+func formInputKind(fieldType int) string {
+	switch fieldType {
+	case TypeText:
+		return "textarea"
+	case TypeNumber:
+		return "number"
+	case TypeSingleSelect:
+		return "select"
+	case TypeMultiSelect:
+		return "checkboxes"
+	case TypeDateTime:
+		return "date"
+	case TypeCheckbox:
+		return "checkbox"
+	case TypeUrl:
+		return "url"
+	case TypePhoneNumber:
+		return "tel"
+	case TypeAttachment:
+		return "file"
+	}
+	return ""
+}
+
+// 按字段类型校验并转换提交的值，未填写时返回 nil
+func formFieldValue(field *AppTableField, values []string) (interface{}, error) {
+	var nonEmpty []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return nil, nil
+	}
+	value := nonEmpty[0]
+	switch *field.Type {
+	case TypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+		if field.Property != nil && field.Property.Min != nil && number < *field.Property.Min {
+			return nil, fmt.Errorf("must be at least %v", *field.Property.Min)
+		}
+		if field.Property != nil && field.Property.Max != nil && number > *field.Property.Max {
+			return nil, fmt.Errorf("must be at most %v", *field.Property.Max)
+		}
+		return number, nil
+	case TypeSingleSelect, TypeMultiSelect:
+		for _, name := range nonEmpty {
+			if _, err := fieldOptionId(field, name); err != nil {
+				return nil, fmt.Errorf("unknown option %q", name)
+			}
+		}
+		if *field.Type == TypeSingleSelect {
+			if len(nonEmpty) > 1 {
+				return nil, errors.New("only one option can be selected")
+			}
+			return value, nil
+		}
+		return nonEmpty, nil
+	case TypeDateTime:
+		for _, layout := range []string{"2006-01-02", "2006-01-02T15:04", "2006-01-02T15:04:05"} {
+			if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+				return t.UnixMilli(), nil
+			}
+		}
+		return nil, errors.New("must be a date")
+	case TypeCheckbox:
+		checked, err := strconv.ParseBool(value)
+		if err != nil && value != "on" {
+			return nil, errors.New("must be checked or unchecked")
+		}
+		if !checked && value != "on" {
+			return nil, nil
+		}
+		return true, nil
+	case TypeUrl:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.New("must be an http or https link")
+		}
+		return map[string]interface{}{"link": value, "text": value}, nil
+	case TypePhoneNumber:
+		for _, r := range value {
+			if !strings.ContainsRune("0123456789+-() ", r) {
+				return nil, errors.New("must be a phone number")
+			}
+		}
+		return value, nil
+	}
+	return value, nil
+}
+
+// 将上传的文件保存到临时目录后上传到多维表格，返回附件字段的值
+func (h *FormHandler) upload(ctx context.Context, headers []*multipart.FileHeader) ([]map[string]interface{}, error) {
+	appToken := h.service.config.AppToken
+	if appToken == "" {
+		return nil, errors.New("app token is empty, uploading files requires the client to be created with an app token")
+	}
+	dir, err := os.MkdirTemp("", "base-form-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	cells := make([]map[string]interface{}, 0, len(headers))
+	for i, header := range headers {
+		path := filepath.Join(dir, strconv.Itoa(i))
+		if err := os.Mkdir(path, 0o700); err != nil {
+			return nil, err
+		}
+		path = filepath.Join(path, sanitizeFileName(filepath.Base(header.Filename)))
+		if err := saveMultipartFile(header, path); err != nil {
+			return nil, err
+		}
+		fileToken, err := h.service.drive().Media.UploadFile(ctx, path, larkdrive.ParentTypeUploadAllMediaBitableFile, appToken, "", h.options...)
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells, map[string]interface{}{attachmentKeyFileToken: fileToken})
+	}
+	return cells, nil
+}
+
+func saveMultipartFile(header *multipart.FileHeader, path string) error {
+	src, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// 返回当前请求使用的 CSRF token，必要时设置 cookie
+func (h *FormHandler) csrfToken(w http.ResponseWriter, r *http.Request) (string, error) {
+	nonce := ""
+	if cookie, err := r.Cookie(formCSRFCookie); err == nil && len(cookie.Value) == 32 {
+		if _, err := hex.DecodeString(cookie.Value); err == nil {
+			nonce = cookie.Value
+		}
+	}
+	if nonce == "" {
+		raw := make([]byte, 16)
+		if _, err := rand.Read(raw); err != nil {
+			return "", err
+		}
+		nonce = hex.EncodeToString(raw)
+		http.SetCookie(w, &http.Cookie{
+			Name:     formCSRFCookie,
+			Value:    nonce,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+	return h.signCSRF(nonce), nil
+}
+
+// 错误详情只写入日志，页面上展示通用的提示
+func (h *FormHandler) logError(ctx context.Context, action string, err error) {
+	if logger := h.service.config.Logger; logger != nil {
+		logger.Error(ctx, fmt.Sprintf("form handler %s/%s: %s: %v", h.tableId, h.formId, action, err))
+	}
+}
+
+func (h *FormHandler) signCSRF(nonce string) string {
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(nonce + "|" + h.tableId + "|" + h.formId))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *FormHandler) validCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(formCSRFCookie)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+	return hmac.Equal([]byte(r.PostFormValue(formCSRFField)), []byte(h.signCSRF(cookie.Value)))
+}
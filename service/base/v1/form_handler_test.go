@@ -0,0 +1,249 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 记录错误日志的 logger
+type testLogger struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (l *testLogger) Debug(context.Context, ...interface{}) {}
+func (l *testLogger) Info(context.Context, ...interface{})  {}
+func (l *testLogger) Warn(context.Context, ...interface{})  {}
+func (l *testLogger) Error(_ context.Context, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, fmt.Sprint(args...))
+}
+
+const testFormNonce = "0123456789abcdef0123456789abcdef"
+
+func newTestFormHandler(t *testing.T) (*FormHandler, *fakeBitableServer, *testLogger) {
+	server := newFakeBitableServer(t)
+	server.form = map[string]interface{}{"name": "Orders"}
+	server.formFields = []map[string]interface{}{
+		{"field_id": "fld1", "title": "Title", "required": true, "visible": true},
+		{"field_id": "fld2", "title": "Amount", "visible": true},
+		{"field_id": "fld3", "title": "Paid", "visible": true},
+		{"field_id": "fld4", "title": "Due", "visible": false},
+	}
+	service := server.service()
+	logger := &testLogger{}
+	service.config.Logger = logger
+	handler, err := service.NewFormHandler("tblOrders", "vewForm")
+	if err != nil {
+		t.Fatalf("NewFormHandler() error = %v", err)
+	}
+	return handler.CSRFSecret([]byte("secret")), server, logger
+}
+
+func newTestFormPost(values url.Values, nonce string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/form", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if nonce != "" {
+		r.AddCookie(&http.Cookie{Name: formCSRFCookie, Value: nonce})
+	}
+	return r
+}
+
+func TestFormHandler_Get(t *testing.T) {
+	handler, _, _ := newTestFormHandler(t)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/form", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != formCSRFCookie {
+		t.Fatalf("cookies = %v, want %s", cookies, formCSRFCookie)
+	}
+	body := w.Body.String()
+	for _, want := range []string{
+		`value="` + handler.signCSRF(cookies[0].Value) + `"`,
+		`<label for="f_fld1">Title *</label>`,
+		`<input type="number" id="f_fld2"`,
+		`<input type="checkbox" id="f_fld3"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page does not contain %s:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "f_fld4") {
+		t.Errorf("page contains the hidden question:\n%s", body)
+	}
+}
+
+func TestFormHandler_Submit(t *testing.T) {
+	valid := func() url.Values {
+		return url.Values{"f_fld1": {"Desk"}, "f_fld2": {"12.5"}, "f_fld3": {"on"}}
+	}
+	tests := []struct {
+		name       string
+		values     url.Values
+		nonce      string
+		token      string
+		wantStatus int
+		wantBody   string
+		wantFields map[string]interface{}
+	}{
+		{
+			name:       "missing_cookie",
+			values:     valid(),
+			wantStatus: http.StatusForbidden,
+			wantBody:   "invalid csrf token",
+		},
+		{
+			name:       "wrong_token",
+			values:     valid(),
+			nonce:      testFormNonce,
+			token:      "forged",
+			wantStatus: http.StatusForbidden,
+			wantBody:   "invalid csrf token",
+		},
+		{
+			name:       "required_missing",
+			values:     url.Values{"f_fld1": {"  "}, "f_fld2": {"12.5"}},
+			nonce:      testFormNonce,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "this field is required",
+		},
+		{
+			name:       "invalid_number",
+			values:     url.Values{"f_fld1": {"Desk"}, "f_fld2": {"many"}},
+			nonce:      testFormNonce,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "must be a number",
+		},
+		{
+			name:       "created",
+			values:     valid(),
+			nonce:      testFormNonce,
+			wantStatus: http.StatusOK,
+			wantBody:   "Submitted, thank you.",
+			wantFields: map[string]interface{}{"Title": "Desk", "Amount": 12.5, "Paid": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, server, _ := newTestFormHandler(t)
+			token := tt.token
+			if token == "" {
+				token = handler.signCSRF(testFormNonce)
+			}
+			tt.values.Set(formCSRFField, token)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newTestFormPost(tt.values, tt.nonce))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("response does not contain %q:\n%s", tt.wantBody, w.Body.String())
+			}
+			var created []*fakeBitableRequest
+			for _, request := range server.find("/tblOrders/records") {
+				if request.method == http.MethodPost {
+					created = append(created, request)
+				}
+			}
+			if tt.wantFields == nil {
+				if len(created) > 0 {
+					t.Errorf("created %d records, want none", len(created))
+				}
+				return
+			}
+			if len(created) != 1 {
+				t.Fatalf("created %d records, want 1", len(created))
+			}
+			if got := created[0].body["fields"]; !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("created fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestFormHandler_RenderError(t *testing.T) {
+	handler, _, logger := newTestFormHandler(t)
+	handler.Template(template.Must(template.New("broken").Parse("{{.Title.Missing}}")))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/form", nil))
+	if len(logger.errors) != 1 || !strings.Contains(logger.errors[0], "render form") {
+		t.Errorf("logged errors = %v, want a render form error", logger.errors)
+	}
+}
+
+func TestFormFieldValue(t *testing.T) {
+	number := NewAppTableFieldBuilder().FieldName("Amount").Type(TypeNumber).
+		Property(NewAppTableFieldPropertyBuilder().Min(1).Max(10).Build()).Build()
+	single := newTestSelectField("Status", TypeSingleSelect, map[string]string{"opt1": "Open", "opt2": "Done"})
+	multi := newTestSelectField("Tags", TypeMultiSelect, map[string]string{"opt1": "Red", "opt2": "Blue"})
+	tests := []struct {
+		name    string
+		field   *AppTableField
+		values  []string
+		want    interface{}
+		wantErr string
+	}{
+		{name: "empty", field: number, values: []string{" ", ""}},
+		{name: "number", field: number, values: []string{"2.5"}, want: 2.5},
+		{name: "number_bounds_inclusive", field: number, values: []string{"10"}, want: 10.0},
+		{name: "number_below_min", field: number, values: []string{"0"}, wantErr: "must be at least 1"},
+		{name: "number_above_max", field: number, values: []string{"11"}, wantErr: "must be at most 10"},
+		{name: "not_a_number", field: number, values: []string{"ten"}, wantErr: "must be a number"},
+		{name: "date", field: newTestField("Due", TypeDateTime), values: []string{"2024-03-01"},
+			want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local).UnixMilli()},
+		{name: "date_time", field: newTestField("Due", TypeDateTime), values: []string{"2024-03-01T09:30"},
+			want: time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local).UnixMilli()},
+		{name: "not_a_date", field: newTestField("Due", TypeDateTime), values: []string{"03/01/2024"}, wantErr: "must be a date"},
+		{name: "checkbox_on", field: newTestField("Paid", TypeCheckbox), values: []string{"on"}, want: true},
+		{name: "checkbox_true", field: newTestField("Paid", TypeCheckbox), values: []string{"true"}, want: true},
+		{name: "checkbox_false", field: newTestField("Paid", TypeCheckbox), values: []string{"false"}},
+		{name: "checkbox_invalid", field: newTestField("Paid", TypeCheckbox), values: []string{"maybe"}, wantErr: "must be checked or unchecked"},
+		{name: "single_select", field: single, values: []string{"Open"}, want: "Open"},
+		{name: "single_select_unknown", field: single, values: []string{"Closed"}, wantErr: `unknown option "Closed"`},
+		{name: "single_select_many", field: single, values: []string{"Open", "Done"}, wantErr: "only one option can be selected"},
+		{name: "multi_select", field: multi, values: []string{"Red", " ", "Blue"}, want: []string{"Red", "Blue"}},
+		{name: "multi_select_unknown", field: multi, values: []string{"Red", "Green"}, wantErr: `unknown option "Green"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formFieldValue(tt.field, tt.values)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("formFieldValue() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("formFieldValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("formFieldValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	FormChangeForm  = "form"  // 更新表单元数据
+	FormChangeField = "field" // 更新表单问题
+)
+
+// FormSpec 以代码声明的表单，可直接在 Go 中构造，也可由 JSON 解析得到
+type FormSpec struct {
+	Name            string           `json:"name"`                        // 表单名称
+	Description     string           `json:"description,omitempty"`       // 表单描述
+	Shared          bool             `json:"shared,omitempty"`            // 是否开启共享
+	SharedLimit     string           `json:"shared_limit,omitempty"`      // 分享范围限制，取值见 SharedLimit* 常量，为空时不修改
+	SubmitLimitOnce bool             `json:"submit_limit_once,omitempty"` // 填写次数限制一次
+	Fields          []*FormFieldSpec `json:"fields"`                      // 按顺序排列的问题，未列出的问题设为不可见；为 nil 时不管理问题
+}
+
+// FormFieldSpec 表单问题
+type FormFieldSpec struct {
+	Field       string `json:"field"`                 // 字段名
+	Title       string `json:"title,omitempty"`       // 问题标题，为空时使用字段名
+	Description string `json:"description,omitempty"` // 问题描述
+	Required    bool   `json:"required,omitempty"`    // 是否必填
+	Hidden      bool   `json:"hidden,omitempty"`      // 是否隐藏，等同于不列出该问题
+}
+
+// 从 JSON 解析表单声明
+func ParseFormSpec(data []byte) (*FormSpec, error) {
+	spec := &FormSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// 校验声明本身，不依赖表单现状
+func (s *FormSpec) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("form spec without name")
+	}
+	if s.SharedLimit != "" && s.SharedLimit != SharedLimitOff && s.SharedLimit != SharedLimitTenantEditable && s.SharedLimit != SharedLimitAnyoneEditable {
+		return fmt.Errorf("invalid shared limit %q", s.SharedLimit)
+	}
+	for _, field := range s.Fields {
+		if field == nil || field.Field == "" {
+			return fmt.Errorf("form field spec without field name")
+		}
+	}
+	return nil
+}
+
+// FormChange 计划中的一项变更
+type FormChange struct {
+	Action    string                    // 变更类型，取值见 FormChange* 常量
+	FieldId   string                    // 表单问题 ID
+	FieldName string                    // 字段名
+	Form      *AppTableForm             // 更新表单时提交的元数据
+	Field     *AppTableFormPatchedField // 更新问题时提交的内容
+	Diffs     []string                  // 具体差异
+}
+
+func (c *FormChange) String() string {
+	if c.Action == FormChangeField {
+		return fmt.Sprintf("%s %s: %s", c.Action, c.FieldName, strings.Join(c.Diffs, "; "))
+	}
+	return fmt.Sprintf("%s: %s", c.Action, strings.Join(c.Diffs, "; "))
+}
+
+// FormPlan 表单声明与表单现状的差异
+type FormPlan struct {
+	TableId string
+	FormId  string
+	Changes []*FormChange
+}
+
+func (p *FormPlan) String() string {
+	if len(p.Changes) == 0 {
+		return "no changes"
+	}
+	lines := make([]string, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 获取表单的全部问题，按表单中的顺序排列
+func (a *appTableFormField) ListAll(ctx context.Context, tableId string, formId string, options ...larkcore.RequestOptionFunc) ([]*AppTableFormField, error) {
+	iterator, err := a.ListByIterator(ctx, NewListAppTableFormFieldReqBuilder().
+		TableId(tableId).
+		FormId(formId).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	var fields []*AppTableFormField
+	for {
+		hasMore, field, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return fields, nil
+		}
+		fields = append(fields, field)
+	}
+}
+
+// 对比表单声明与表单现状，生成变更计划，不做任何修改
+//
+// - 问题按字段名与数据表字段匹配，顺序与声明不一致时为所有可见问题重新指定位置
+func (a *appTableForm) Plan(ctx context.Context, tableId string, formId string, spec *FormSpec, options ...larkcore.RequestOptionFunc) (*FormPlan, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	resp, err := a.Get(ctx, NewGetAppTableFormReqBuilder().
+		TableId(tableId).
+		FormId(formId).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, resp.CodeError
+	}
+	current := &AppTableForm{}
+	if resp.Data != nil && resp.Data.Form != nil {
+		current = resp.Data.Form
+	}
+
+	plan := &FormPlan{TableId: tableId, FormId: formId}
+	desired := NewAppTableFormBuilder().
+		Name(spec.Name).
+		Description(spec.Description).
+		Shared(spec.Shared).
+		SubmitLimitOnce(spec.SubmitLimitOnce)
+	if spec.SharedLimit != "" {
+		desired.SharedLimit(spec.SharedLimit)
+	}
+	form := desired.Build()
+	if diffs := diffAppTableForm(current, form); len(diffs) > 0 {
+		plan.Changes = append(plan.Changes, &FormChange{Action: FormChangeForm, Form: form, Diffs: diffs})
+	}
+	if spec.Fields == nil {
+		return plan, nil
+	}
+
+	tableFields, err := a.service.AppTableField.ListAll(ctx, tableId, options...)
+	if err != nil {
+		return nil, err
+	}
+	fieldIds := map[string]string{}
+	fieldNames := map[string]string{}
+	for _, field := range tableFields {
+		if field.FieldId != nil && field.FieldName != nil {
+			fieldIds[*field.FieldName] = *field.FieldId
+			fieldNames[*field.FieldId] = *field.FieldName
+		}
+	}
+	questions, err := a.service.AppTableFormField.ListAll(ctx, tableId, formId, options...)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]*AppTableFormField{}
+	var currentOrder []string
+	for _, question := range questions {
+		if question.FieldId == nil {
+			continue
+		}
+		existing[*question.FieldId] = question
+		if boolValue(question.Visible) {
+			currentOrder = append(currentOrder, *question.FieldId)
+		}
+	}
+
+	var visible []*FormFieldSpec
+	var desiredOrder []string
+	seen := map[string]bool{}
+	for _, field := range spec.Fields {
+		fieldId, ok := fieldIds[field.Field]
+		if !ok {
+			return nil, fmt.Errorf("field %s not found", field.Field)
+		}
+		if _, ok := existing[fieldId]; !ok {
+			return nil, fmt.Errorf("field %s is not a question of form %s", field.Field, formId)
+		}
+		if seen[fieldId] {
+			return nil, fmt.Errorf("duplicate form field spec %s", field.Field)
+		}
+		seen[fieldId] = true
+		if !field.Hidden {
+			visible = append(visible, field)
+			desiredOrder = append(desiredOrder, fieldId)
+		}
+	}
+	reorder := strings.Join(currentOrder, ",") != strings.Join(desiredOrder, ",")
+
+	shown := map[string]bool{}
+	for i, field := range visible {
+		fieldId := desiredOrder[i]
+		shown[fieldId] = true
+		title := field.Title
+		if title == "" {
+			title = field.Field
+		}
+		patched := NewAppTableFormPatchedFieldBuilder().
+			Title(title).
+			Description(field.Description).
+			Required(field.Required).
+			Visible(true)
+		diffs := diffAppTableFormField(existing[fieldId], title, field.Description, field.Required)
+		if reorder {
+			preFieldId := ""
+			if i > 0 {
+				preFieldId = desiredOrder[i-1]
+			}
+			patched.PreFieldId(preFieldId)
+			diffs = append(diffs, fmt.Sprintf("position: %d", i+1))
+		}
+		if len(diffs) > 0 {
+			plan.Changes = append(plan.Changes, &FormChange{Action: FormChangeField, FieldId: fieldId, FieldName: field.Field, Field: patched.Build(), Diffs: diffs})
+		}
+	}
+	// 隐藏问题时不允许修改其它属性
+	for _, fieldId := range currentOrder {
+		if !shown[fieldId] {
+			plan.Changes = append(plan.Changes, &FormChange{
+				Action:    FormChangeField,
+				FieldId:   fieldId,
+				FieldName: fieldNames[fieldId],
+				Field:     NewAppTableFormPatchedFieldBuilder().Visible(false).Build(),
+				Diffs:     []string{"visible: true -> false"},
+			})
+		}
+	}
+	return plan, nil
+}
+
+// 按顺序执行变更计划
+func (a *appTableForm) Apply(ctx context.Context, plan *FormPlan, options ...larkcore.RequestOptionFunc) error {
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case FormChangeForm:
+			resp, err := a.Patch(ctx, NewPatchAppTableFormReqBuilder().
+				TableId(plan.TableId).
+				FormId(plan.FormId).
+				AppTableForm(change.Form).
+				Build(), options...)
+			if err != nil {
+				return err
+			}
+			if !resp.Success() {
+				return resp.CodeError
+			}
+		case FormChangeField:
+			resp, err := a.service.AppTableFormField.Patch(ctx, NewPatchAppTableFormFieldReqBuilder().
+				TableId(plan.TableId).
+				FormId(plan.FormId).
+				FieldId(change.FieldId).
+				AppTableFormPatchedField(change.Field).
+				Build(), options...)
+			if err != nil {
+				return err
+			}
+			if !resp.Success() {
+				return resp.CodeError
+			}
+		}
+	}
+	return nil
+}
+
+func diffAppTableForm(current *AppTableForm, desired *AppTableForm) []string {
+	var diffs []string
+	if stringValue(current.Name) != stringValue(desired.Name) {
+		diffs = append(diffs, fmt.Sprintf("name: %q -> %q", stringValue(current.Name), stringValue(desired.Name)))
+	}
+	if stringValue(current.Description) != stringValue(desired.Description) {
+		diffs = append(diffs, fmt.Sprintf("description: %q -> %q", stringValue(current.Description), stringValue(desired.Description)))
+	}
+	if boolValue(current.Shared) != boolValue(desired.Shared) {
+		diffs = append(diffs, fmt.Sprintf("shared: %v -> %v", boolValue(current.Shared), boolValue(desired.Shared)))
+	}
+	if desired.SharedLimit != nil && stringValue(current.SharedLimit) != *desired.SharedLimit {
+		diffs = append(diffs, fmt.Sprintf("shared_limit: %s -> %s", stringValue(current.SharedLimit), *desired.SharedLimit))
+	}
+	if boolValue(current.SubmitLimitOnce) != boolValue(desired.SubmitLimitOnce) {
+		diffs = append(diffs, fmt.Sprintf("submit_limit_once: %v -> %v", boolValue(current.SubmitLimitOnce), boolValue(desired.SubmitLimitOnce)))
+	}
+	return diffs
+}
+
+func diffAppTableFormField(current *AppTableFormField, title string, description string, required bool) []string {
+	var diffs []string
+	if !boolValue(current.Visible) {
+		diffs = append(diffs, "visible: false -> true")
+	}
+	if stringValue(current.Title) != title {
+		diffs = append(diffs, fmt.Sprintf("title: %q -> %q", stringValue(current.Title), title))
+	}
+	if stringValue(current.Description) != description {
+		diffs = append(diffs, fmt.Sprintf("description: %q -> %q", stringValue(current.Description), description))
+	}
+	if boolValue(current.Required) != required {
+		diffs = append(diffs, fmt.Sprintf("required: %v -> %v", boolValue(current.Required), required))
+	}
+	return diffs
+}
@@ -0,0 +1,142 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func newTestFormQuestion(fieldId string, title string, visible bool) map[string]interface{} {
+	return map[string]interface{}{"field_id": fieldId, "title": title, "visible": visible}
+}
+
+func TestAppTableForm_Plan(t *testing.T) {
+	form := map[string]interface{}{"name": "Orders", "shared": true, "shared_limit": SharedLimitOff}
+	questions := []map[string]interface{}{
+		newTestFormQuestion("fld1", "Title", true),
+		newTestFormQuestion("fld2", "Amount", true),
+		newTestFormQuestion("fld3", "Paid", false),
+	}
+	type change struct {
+		fieldId    string
+		preFieldId *string
+		diffs      []string
+	}
+	first, afterAmount, afterTitle := "", "fld2", "fld1"
+	tests := []struct {
+		name string
+		spec *FormSpec
+		want []change
+	}{
+		{
+			name: "unchanged",
+			spec: &FormSpec{Name: "Orders", Shared: true, Fields: []*FormFieldSpec{{Field: "Title"}, {Field: "Amount"}}},
+		},
+		{
+			name: "fields_unmanaged",
+			spec: &FormSpec{Name: "Orders", Shared: true, SharedLimit: SharedLimitTenantEditable},
+			want: []change{{diffs: []string{"shared_limit: off -> tenant_editable"}}},
+		},
+		{
+			name: "form_metadata",
+			spec: &FormSpec{Name: "Order intake", Description: "New orders", SubmitLimitOnce: true, Fields: []*FormFieldSpec{{Field: "Title"}, {Field: "Amount"}}},
+			want: []change{{diffs: []string{
+				`name: "Orders" -> "Order intake"`,
+				`description: "" -> "New orders"`,
+				"shared: true -> false",
+				"submit_limit_once: false -> true",
+			}}},
+		},
+		{
+			name: "question_attributes",
+			spec: &FormSpec{Name: "Orders", Shared: true, Fields: []*FormFieldSpec{
+				{Field: "Title", Title: "Order title", Required: true},
+				{Field: "Amount", Description: "In dollars"},
+			}},
+			want: []change{
+				{fieldId: "fld1", diffs: []string{`title: "Title" -> "Order title"`, "required: false -> true"}},
+				{fieldId: "fld2", diffs: []string{`description: "" -> "In dollars"`}},
+			},
+		},
+		{
+			name: "reorder_and_show",
+			spec: &FormSpec{Name: "Orders", Shared: true, Fields: []*FormFieldSpec{{Field: "Amount"}, {Field: "Title"}, {Field: "Paid"}}},
+			want: []change{
+				{fieldId: "fld2", preFieldId: &first, diffs: []string{"position: 1"}},
+				{fieldId: "fld1", preFieldId: &afterAmount, diffs: []string{"position: 2"}},
+				{fieldId: "fld3", preFieldId: &afterTitle, diffs: []string{"visible: false -> true", "position: 3"}},
+			},
+		},
+		{
+			name: "hide_unlisted_and_hidden",
+			spec: &FormSpec{Name: "Orders", Shared: true, Fields: []*FormFieldSpec{{Field: "Title", Hidden: true}}},
+			want: []change{
+				{fieldId: "fld1", diffs: []string{"visible: true -> false"}},
+				{fieldId: "fld2", diffs: []string{"visible: true -> false"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeBitableServer(t)
+			server.form = form
+			server.formFields = questions
+			plan, err := server.service().AppTableForm.Plan(context.Background(), "tblOrders", "vewForm", tt.spec)
+			if err != nil {
+				t.Fatalf("Plan() error = %v", err)
+			}
+			var got []change
+			for _, c := range plan.Changes {
+				got = append(got, change{fieldId: c.FieldId, diffs: c.Diffs})
+				if c.Action == FormChangeField {
+					got[len(got)-1].preFieldId = c.Field.PreFieldId
+					if c.Diffs[0] == "visible: true -> false" && (c.Field.Title != nil || c.Field.PreFieldId != nil) {
+						t.Errorf("hidden question %s patched with other attributes", c.FieldId)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Plan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppTableForm_PlanInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec *FormSpec
+	}{
+		{name: "without_name", spec: &FormSpec{}},
+		{name: "shared_limit", spec: &FormSpec{Name: "Orders", SharedLimit: "everyone"}},
+		{name: "field_without_name", spec: &FormSpec{Name: "Orders", Fields: []*FormFieldSpec{{Title: "Title"}}}},
+		{name: "unknown_field", spec: &FormSpec{Name: "Orders", Fields: []*FormFieldSpec{{Field: "Missing"}}}},
+		{name: "not_a_question", spec: &FormSpec{Name: "Orders", Fields: []*FormFieldSpec{{Field: "Due"}}}},
+		{name: "duplicate_field", spec: &FormSpec{Name: "Orders", Fields: []*FormFieldSpec{{Field: "Title"}, {Field: "Title"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeBitableServer(t)
+			server.form = map[string]interface{}{"name": "Orders"}
+			server.formFields = []map[string]interface{}{newTestFormQuestion("fld1", "Title", true)}
+			if _, err := server.service().AppTableForm.Plan(context.Background(), "tblOrders", "vewForm", tt.spec); err == nil {
+				t.Fatalf("Plan() expected an error")
+			}
+			if tt.spec.validate() != nil && len(server.requests) > 0 {
+				t.Errorf("invalid spec sent %d requests", len(server.requests))
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 按记录ID筛选时，单个筛选公式中包含的记录ID数
+const linkFetchBatch = 50
+
+// LinkResolver 将单向关联、双向关联字段中的记录ID展开为被关联的记录
+//
+// - 展开后字段值由记录ID列表替换为 []*AppTableRecord，被关联记录中的关联字段按 depth 继续展开
+//
+// - 同一次 Resolve 中，各数据表的字段和已获取的记录会被缓存，避免重复请求
+//
+// - 被关联记录已删除或无权访问时不会报错，对应位置保留只有 RecordId 的记录
+type LinkResolver struct {
+	service *BaseService
+	depth   int
+	options []larkcore.RequestOptionFunc
+}
+
+// 创建关联记录解析器，depth 为展开层数，1 表示只展开传入记录的关联字段
+func (b *BaseService) NewLinkResolver(depth int, options ...larkcore.RequestOptionFunc) *LinkResolver {
+	return &LinkResolver{service: b, depth: depth, options: options}
+}
+
+// 就地展开数据表中一批记录的关联字段
+func (r *LinkResolver) Resolve(ctx context.Context, tableId string, records []*AppTableRecord) error {
+	call := &linkResolveCall{
+		resolver: r,
+		fields:   map[string][]*AppTableField{},
+		records:  map[string]map[string]*AppTableRecord{},
+	}
+	return call.expand(ctx, tableId, records, r.depth)
+}
+
+// linkResolveCall 一次 Resolve 内的缓存
+type linkResolveCall struct {
+	resolver *LinkResolver
+	fields   map[string][]*AppTableField           // table_id -> 字段
+	records  map[string]map[string]*AppTableRecord // table_id -> record_id -> 记录，获取不到的记录为 nil
+}
+
+func (c *linkResolveCall) expand(ctx context.Context, tableId string, records []*AppTableRecord, depth int) error {
+	if depth <= 0 || len(records) == 0 {
+		return nil
+	}
+	linkFields, err := c.linkFields(ctx, tableId)
+	if err != nil {
+		return err
+	}
+	if len(linkFields) == 0 {
+		return nil
+	}
+
+	// 按目标表汇总需要获取的记录ID
+	needed := map[string][]string{}
+	for _, record := range records {
+		for _, field := range linkFields {
+			targetTableId := *field.Property.TableId
+			needed[targetTableId] = append(needed[targetTableId], linkCellRecordIds(record.Fields[*field.FieldName])...)
+		}
+	}
+	for targetTableId, recordIds := range needed {
+		if err := c.fetch(ctx, targetTableId, recordIds); err != nil {
+			return err
+		}
+	}
+
+	// 替换为被关联记录的副本，副本之间互不共享，避免多层展开后出现环
+	expanded := map[string][]*AppTableRecord{}
+	for _, record := range records {
+		for _, field := range linkFields {
+			value, ok := record.Fields[*field.FieldName]
+			if !ok {
+				continue
+			}
+			if _, ok := value.([]*AppTableRecord); ok {
+				continue
+			}
+			targetTableId := *field.Property.TableId
+			linked := []*AppTableRecord{}
+			for _, recordId := range linkCellRecordIds(value) {
+				target := c.records[targetTableId][recordId]
+				if target == nil {
+					id := recordId
+					linked = append(linked, &AppTableRecord{RecordId: &id, Fields: map[string]interface{}{}})
+					continue
+				}
+				linked = append(linked, copyRecord(target))
+			}
+			record.Fields[*field.FieldName] = linked
+			expanded[targetTableId] = append(expanded[targetTableId], linked...)
+		}
+	}
+	for targetTableId, linked := range expanded {
+		if err := c.expand(ctx, targetTableId, linked, depth-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 数据表中关联到其它表的字段
+func (c *linkResolveCall) linkFields(ctx context.Context, tableId string) ([]*AppTableField, error) {
+	fields, ok := c.fields[tableId]
+	if !ok {
+		var err error
+		fields, err = c.resolver.service.AppTableField.ListAll(ctx, tableId, c.resolver.options...)
+		if err != nil {
+			return nil, err
+		}
+		c.fields[tableId] = fields
+	}
+	var linkFields []*AppTableField
+	for _, field := range fields {
+		if field.Type == nil || field.FieldName == nil || field.Property == nil || field.Property.TableId == nil {
+			continue
+		}
+		if *field.Type == TypeLink || *field.Type == TypeDuplexLink {
+			linkFields = append(linkFields, field)
+		}
+	}
+	return linkFields, nil
+}
+
+// 获取缓存中没有的记录，按记录ID分批筛选
+func (c *linkResolveCall) fetch(ctx context.Context, tableId string, recordIds []string) error {
+	cache, ok := c.records[tableId]
+	if !ok {
+		cache = map[string]*AppTableRecord{}
+		c.records[tableId] = cache
+	}
+	var missing []string
+	for _, recordId := range recordIds {
+		if _, ok := cache[recordId]; ok {
+			continue
+		}
+		// 先占位，筛选结果中没有的记录保持为 nil
+		cache[recordId] = nil
+		missing = append(missing, recordId)
+	}
+
+	for start := 0; start < len(missing); start += linkFetchBatch {
+		end := start + linkFetchBatch
+		if end > len(missing) {
+			end = len(missing)
+		}
+		conditions := make([]string, 0, end-start)
+		for _, recordId := range missing[start:end] {
+			conditions = append(conditions, "RECORD_ID()="+sqlFormulaString(recordId))
+		}
+		iterator, err := c.resolver.service.AppTableRecord.ListByIterator(ctx, NewListAppTableRecordReqBuilder().
+			TableId(tableId).
+			Filter("OR("+strings.Join(conditions, ", ")+")").
+			Build(), c.resolver.options...)
+		if err != nil {
+			return err
+		}
+		for {
+			hasMore, record, err := iterator.Next()
+			if err != nil {
+				return err
+			}
+			if !hasMore {
+				break
+			}
+			if record.RecordId != nil {
+				cache[*record.RecordId] = record
+			}
+		}
+	}
+	return nil
+}
+
+// 未展开的关联字段值中的记录ID
+func linkCellRecordIds(value interface{}) []string {
+	if value == nil {
+		return nil
+	}
+	if _, ok := value.([]*AppTableRecord); ok {
+		return nil
+	}
+	return mirrorLinkRecordIds(value)
+}
+
+func copyRecord(record *AppTableRecord) *AppTableRecord {
+	fields := make(map[string]interface{}, len(record.Fields))
+	for name, value := range record.Fields {
+		fields[name] = value
+	}
+	return &AppTableRecord{
+		RecordId:         record.RecordId,
+		CreatedBy:        record.CreatedBy,
+		CreatedTime:      record.CreatedTime,
+		LastModifiedBy:   record.LastModifiedBy,
+		LastModifiedTime: record.LastModifiedTime,
+		Fields:           fields,
+	}
+}
+
+// 将记录解码到结构体
+//
+// - 以字段名作为 json key，另有 record_id；已展开的关联字段解码为同样结构的对象数组
+//
+// - 例如 struct { RecordId string `json:"record_id"`; Customer []Customer `json:"客户"` }
+func DecodeRecord(record *AppTableRecord, v interface{}) error {
+	data, err := json.Marshal(flattenRecord(record))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func flattenRecord(record *AppTableRecord) map[string]interface{} {
+	flat := make(map[string]interface{}, len(record.Fields)+1)
+	for name, value := range record.Fields {
+		if linked, ok := value.([]*AppTableRecord); ok {
+			items := make([]map[string]interface{}, 0, len(linked))
+			for _, item := range linked {
+				items = append(items, flattenRecord(item))
+			}
+			value = items
+		}
+		flat[name] = value
+	}
+	if record.RecordId != nil {
+		flat[MirrorColumnRecordId] = *record.RecordId
+	}
+	return flat
+}
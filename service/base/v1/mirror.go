@@ -0,0 +1,812 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 镜像表中每张表都带有的系统列
+const (
+	MirrorColumnRecordId         = "record_id"
+	MirrorColumnCreatedTime      = "created_time"
+	MirrorColumnLastModifiedTime = "last_modified_time"
+)
+
+// QueryResult 本地镜像的查询结果
+//
+// - 数字、日期（毫秒时间戳）为 float64，复选框为 bool，多选、人员、附件、关联等多值字段为 []string，其余为 string
+type QueryResult struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// Mirror 将若干数据表同步到内存中，并支持用 SQL 查询
+//
+// - 支持 SELECT、WHERE、GROUP BY、HAVING、ORDER BY、LIMIT/OFFSET，以及 [LEFT] JOIN ... ON
+//
+// - 多值字段与单值比较时按“包含”处理，因此关联字段可直接 JOIN：ON o.客户 = c.record_id
+//
+// - 通过 Refresh 或 Run 增量同步保持数据最新，查询只读本地数据，不会请求接口
+//
+// - 增量同步依赖数据表中的“最后更新时间”字段；没有该字段的数据表每次刷新都会拉取全部记录
+type Mirror struct {
+	service *BaseService
+	options []larkcore.RequestOptionFunc
+
+	mu     sync.RWMutex
+	tables map[string]*mirrorTable // 小写表名 -> 表
+}
+
+// mirrorTable 镜像中的一张表，同时作为其同步器的 SyncStore，记录只在这里保存一份
+type mirrorTable struct {
+	mirror  *Mirror
+	name    string
+	tableId string
+	syncer  *Syncer
+	refresh sync.Mutex // 串行化同一张表的同步
+
+	// 以下字段由 mirror.mu 保护
+	fields  []*AppTableField
+	columns []string
+	types   map[string]int    // 小写列名 -> 字段类型
+	names   map[string]string // 小写列名 -> 列名
+	state   *SyncState
+	records map[string]*AppTableRecord
+	rows    map[string]map[string]interface{} // 记录ID -> 已转换类型的行
+	sorted  []map[string]interface{}          // 按记录ID排序的行，有变更时在同步结束后重建
+	dirty   bool
+}
+
+// 创建本地镜像
+func (b *BaseService) NewMirror(options ...larkcore.RequestOptionFunc) *Mirror {
+	return &Mirror{service: b, options: options, tables: map[string]*mirrorTable{}}
+}
+
+// 将数据表加入镜像并完成首次同步，name 为 SQL 中引用该表时使用的表名
+func (m *Mirror) AddTable(ctx context.Context, name string, tableId string) error {
+	key := strings.ToLower(name)
+	m.mu.Lock()
+	if _, ok := m.tables[key]; ok {
+		m.mu.Unlock()
+		return fmt.Errorf("mirror table %s already exists", name)
+	}
+	table := &mirrorTable{
+		mirror:  m,
+		name:    name,
+		tableId: tableId,
+		records: map[string]*AppTableRecord{},
+		rows:    map[string]map[string]interface{}{},
+	}
+	table.syncer = m.service.NewSyncer(tableId, table, nil, m.options...)
+	m.tables[key] = table
+	m.mu.Unlock()
+
+	if err := m.refresh(ctx, table); err != nil {
+		m.mu.Lock()
+		delete(m.tables, key)
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// 重新获取字段定义并增量同步全部数据表
+func (m *Mirror) Refresh(ctx context.Context) error {
+	m.mu.RLock()
+	tables := make([]*mirrorTable, 0, len(m.tables))
+	for _, table := range m.tables {
+		tables = append(tables, table)
+	}
+	m.mu.RUnlock()
+
+	for _, table := range tables {
+		if err := m.refresh(ctx, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 每隔 interval 执行一次 Refresh，直到 ctx 结束或同步失败
+func (m *Mirror) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := m.Refresh(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *Mirror) refresh(ctx context.Context, table *mirrorTable) error {
+	table.refresh.Lock()
+	defer table.refresh.Unlock()
+
+	fields, err := m.service.AppTableField.ListAll(ctx, table.tableId, m.options...)
+	if err != nil {
+		return err
+	}
+	table.syncer.useFields(fields)
+	m.mu.Lock()
+	table.setFields(fields)
+	m.mu.Unlock()
+
+	err = table.syncer.Sync(ctx)
+	m.mu.Lock()
+	table.rebuild()
+	m.mu.Unlock()
+	return err
+}
+
+// 更新字段定义，列或类型有变化时重新转换全部行
+func (t *mirrorTable) setFields(fields []*AppTableField) {
+	columns := []string{MirrorColumnRecordId}
+	types := map[string]int{}
+	names := map[string]string{MirrorColumnRecordId: MirrorColumnRecordId}
+	for _, field := range fields {
+		if field.FieldName == nil || field.Type == nil {
+			continue
+		}
+		key := strings.ToLower(*field.FieldName)
+		columns = append(columns, *field.FieldName)
+		types[key] = *field.Type
+		names[key] = *field.FieldName
+	}
+	for _, column := range []string{MirrorColumnCreatedTime, MirrorColumnLastModifiedTime} {
+		if _, ok := names[column]; !ok {
+			columns = append(columns, column)
+			names[column] = column
+		}
+	}
+	changed := !reflect.DeepEqual(columns, t.columns) || !reflect.DeepEqual(types, t.types)
+	t.fields, t.columns, t.types, t.names = fields, columns, types, names
+	if changed {
+		for recordId, record := range t.records {
+			t.rows[recordId] = t.row(record)
+		}
+		t.dirty = true
+	}
+}
+
+// 按记录ID重新排列行
+func (t *mirrorTable) rebuild() {
+	if !t.dirty {
+		return
+	}
+	recordIds := make([]string, 0, len(t.rows))
+	for recordId := range t.rows {
+		recordIds = append(recordIds, recordId)
+	}
+	sort.Strings(recordIds)
+	t.sorted = make([]map[string]interface{}, 0, len(recordIds))
+	for _, recordId := range recordIds {
+		t.sorted = append(t.sorted, t.rows[recordId])
+	}
+	t.dirty = false
+}
+
+// 将记录转换为查询使用的行
+func (t *mirrorTable) row(record *AppTableRecord) map[string]interface{} {
+	row := map[string]interface{}{MirrorColumnRecordId: *record.RecordId}
+	if record.CreatedTime != nil {
+		row[MirrorColumnCreatedTime] = float64(*record.CreatedTime)
+	}
+	if record.LastModifiedTime != nil {
+		row[MirrorColumnLastModifiedTime] = float64(*record.LastModifiedTime)
+	}
+	for name, value := range record.Fields {
+		key := strings.ToLower(name)
+		if fieldType, ok := t.types[key]; ok {
+			row[key] = mirrorCellValue(fieldType, value)
+		}
+	}
+	return row
+}
+
+func (t *mirrorTable) LoadState(ctx context.Context, tableId string) (*SyncState, error) {
+	t.mirror.mu.RLock()
+	defer t.mirror.mu.RUnlock()
+	if t.state == nil {
+		return nil, nil
+	}
+	state := *t.state
+	return &state, nil
+}
+
+func (t *mirrorTable) SaveState(ctx context.Context, tableId string, state *SyncState) error {
+	t.mirror.mu.Lock()
+	defer t.mirror.mu.Unlock()
+	saved := *state
+	t.state = &saved
+	return nil
+}
+
+func (t *mirrorTable) GetRecord(ctx context.Context, tableId string, recordId string) (*AppTableRecord, error) {
+	t.mirror.mu.RLock()
+	defer t.mirror.mu.RUnlock()
+	return t.records[recordId], nil
+}
+
+func (t *mirrorTable) PutRecord(ctx context.Context, tableId string, record *AppTableRecord) error {
+	t.mirror.mu.Lock()
+	defer t.mirror.mu.Unlock()
+	t.records[*record.RecordId] = record
+	t.rows[*record.RecordId] = t.row(record)
+	t.dirty = true
+	return nil
+}
+
+func (t *mirrorTable) DeleteRecord(ctx context.Context, tableId string, recordId string) error {
+	t.mirror.mu.Lock()
+	defer t.mirror.mu.Unlock()
+	delete(t.records, recordId)
+	delete(t.rows, recordId)
+	t.dirty = true
+	return nil
+}
+
+func (t *mirrorTable) RecordIds(ctx context.Context, tableId string) ([]string, error) {
+	t.mirror.mu.RLock()
+	defer t.mirror.mu.RUnlock()
+	recordIds := make([]string, 0, len(t.records))
+	for recordId := range t.records {
+		recordIds = append(recordIds, recordId)
+	}
+	return recordIds, nil
+}
+
+// 在本地镜像上执行 SELECT 查询，? 占位符按顺序取 args
+func (m *Mirror) Query(ctx context.Context, query string, args ...interface{}) (*QueryResult, error) {
+	stmt, err := parseSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	selectStmt, ok := stmt.(*sqlSelect)
+	if !ok {
+		return nil, fmt.Errorf("mirror only supports SELECT statements")
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	q := &mirrorQuery{ctx: ctx, mirror: m, stmt: selectStmt, args: args}
+	return q.run()
+}
+
+// mirrorSource 查询中的一张表及其已转换好类型的行
+type mirrorSource struct {
+	ref     sqlTableRef
+	table   *mirrorTable
+	columns []string
+	types   map[string]int // 小写列名 -> 字段类型
+	names   map[string]string
+	rows    []map[string]interface{}
+}
+
+type mirrorQuery struct {
+	ctx     context.Context
+	mirror  *Mirror
+	stmt    *sqlSelect
+	args    []interface{}
+	sources []*mirrorSource
+}
+
+// mirrorRowEnv 连接后的一行，rows 与 sources 一一对应，LEFT JOIN 未匹配时为 nil
+type mirrorRowEnv struct {
+	q    *mirrorQuery
+	rows []map[string]interface{}
+}
+
+func (env *mirrorRowEnv) column(ref *sqlColumnRef) (interface{}, error) {
+	found := -1
+	for i, source := range env.q.sources {
+		if ref.table != "" && !strings.EqualFold(ref.table, source.ref.name) && !strings.EqualFold(ref.table, source.ref.alias) {
+			continue
+		}
+		if _, ok := source.names[strings.ToLower(ref.name)]; !ok {
+			continue
+		}
+		if found >= 0 {
+			return nil, fmt.Errorf("sql: column %s is ambiguous", ref.name)
+		}
+		found = i
+	}
+	if found < 0 {
+		if ref.table != "" {
+			return nil, fmt.Errorf("sql: unknown column %s.%s", ref.table, ref.name)
+		}
+		return nil, fmt.Errorf("sql: unknown column %s", ref.name)
+	}
+	row := env.rows[found]
+	if row == nil {
+		return nil, nil
+	}
+	return row[strings.ToLower(ref.name)], nil
+}
+
+func (env *mirrorRowEnv) aggregate(call *sqlCall) (interface{}, error) {
+	return nil, fmt.Errorf("sql: aggregate %s is not allowed here", call.name)
+}
+
+// mirrorGroupEnv 分组后的一组行，普通列取组内第一行的值
+type mirrorGroupEnv struct {
+	q    *mirrorQuery
+	rows []sqlEnv
+}
+
+func (env *mirrorGroupEnv) column(ref *sqlColumnRef) (interface{}, error) {
+	if len(env.rows) == 0 {
+		return nil, nil
+	}
+	return env.rows[0].column(ref)
+}
+
+func (env *mirrorGroupEnv) aggregate(call *sqlCall) (interface{}, error) {
+	return evalSQLAggregate(call, env.rows, env.q.args)
+}
+
+type mirrorOutputRow struct {
+	env    sqlEnv
+	values []interface{}
+}
+
+func (q *mirrorQuery) run() (*QueryResult, error) {
+	refs := append([]sqlTableRef{q.stmt.from}, make([]sqlTableRef, 0, len(q.stmt.joins))...)
+	for _, join := range q.stmt.joins {
+		refs = append(refs, join.table)
+	}
+	for _, ref := range refs {
+		table, ok := q.mirror.tables[strings.ToLower(ref.name)]
+		if !ok {
+			return nil, fmt.Errorf("sql: unknown table %s", ref.name)
+		}
+		q.sources = append(q.sources, newMirrorSource(ref, table))
+	}
+
+	// FROM 与 JOIN：逐表嵌套循环连接
+	envs := make([]*mirrorRowEnv, 0, len(q.sources[0].rows))
+	for _, row := range q.sources[0].rows {
+		envs = append(envs, &mirrorRowEnv{q: q, rows: []map[string]interface{}{row}})
+	}
+	for i, join := range q.stmt.joins {
+		source := q.sources[i+1]
+		var joined []*mirrorRowEnv
+		for _, env := range envs {
+			if err := q.ctx.Err(); err != nil {
+				return nil, err
+			}
+			matched := false
+			for _, row := range source.rows {
+				candidate := &mirrorRowEnv{q: q, rows: append(append([]map[string]interface{}{}, env.rows...), row)}
+				ok, err := q.match(join.on, candidate)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					joined = append(joined, candidate)
+					matched = true
+				}
+			}
+			if !matched && join.left {
+				joined = append(joined, &mirrorRowEnv{q: q, rows: append(append([]map[string]interface{}{}, env.rows...), nil)})
+			}
+		}
+		envs = joined
+	}
+	// 补齐未参与连接的表，使列解析时下标一致
+	for _, env := range envs {
+		for len(env.rows) < len(q.sources) {
+			env.rows = append(env.rows, nil)
+		}
+	}
+
+	var filtered []sqlEnv
+	for _, env := range envs {
+		ok, err := q.match(q.stmt.where, env)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, env)
+		}
+	}
+
+	result := &QueryResult{}
+	var items []*sqlSelectItem
+	aggregated := len(q.stmt.groupBy) > 0 || q.stmt.having != nil
+	for _, item := range q.stmt.items {
+		if !item.star {
+			items = append(items, item)
+			result.Columns = append(result.Columns, sqlExprName(item))
+			aggregated = aggregated || sqlHasAggregate(item.expr)
+			continue
+		}
+		for _, source := range q.sources {
+			if item.starTable != "" && !strings.EqualFold(item.starTable, source.ref.name) && !strings.EqualFold(item.starTable, source.ref.alias) {
+				continue
+			}
+			for _, column := range source.columns {
+				table := source.ref.alias
+				if table == "" {
+					table = source.ref.name
+				}
+				items = append(items, &sqlSelectItem{expr: &sqlColumnRef{table: table, name: column}})
+				result.Columns = append(result.Columns, column)
+			}
+		}
+	}
+
+	// 分组或存在聚合函数时，按组输出
+	rowEnvs := filtered
+	if aggregated {
+		groups, err := q.group(filtered)
+		if err != nil {
+			return nil, err
+		}
+		rowEnvs = groups
+	}
+
+	var outputs []*mirrorOutputRow
+	for _, env := range rowEnvs {
+		if q.stmt.having != nil {
+			ok, err := q.match(q.stmt.having, env)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		output := &mirrorOutputRow{env: env, values: make([]interface{}, 0, len(items))}
+		for _, item := range items {
+			value, err := evalSQL(item.expr, env, q.args)
+			if err != nil {
+				return nil, err
+			}
+			output.values = append(output.values, value)
+		}
+		outputs = append(outputs, output)
+	}
+
+	if err := q.order(outputs, result.Columns); err != nil {
+		return nil, err
+	}
+	outputs, err := q.limit(outputs)
+	if err != nil {
+		return nil, err
+	}
+	for _, output := range outputs {
+		result.Rows = append(result.Rows, output.values)
+	}
+	return result, nil
+}
+
+func (q *mirrorQuery) match(expr sqlExpr, env sqlEnv) (bool, error) {
+	if expr == nil {
+		return true, nil
+	}
+	value, err := evalSQL(expr, env, q.args)
+	if err != nil {
+		return false, err
+	}
+	return sqlTruthy(value), nil
+}
+
+func (q *mirrorQuery) group(envs []sqlEnv) ([]sqlEnv, error) {
+	// 没有 GROUP BY 的聚合查询，全部行为一组
+	if len(q.stmt.groupBy) == 0 {
+		return []sqlEnv{&mirrorGroupEnv{q: q, rows: envs}}, nil
+	}
+	var groups []sqlEnv
+	index := map[string]*mirrorGroupEnv{}
+	for _, env := range envs {
+		keys := make([]string, 0, len(q.stmt.groupBy))
+		for _, expr := range q.stmt.groupBy {
+			value, err := evalSQL(expr, env, q.args)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, sqlKey(value))
+		}
+		key := strings.Join(keys, "\x00")
+		group, ok := index[key]
+		if !ok {
+			group = &mirrorGroupEnv{q: q}
+			index[key] = group
+			groups = append(groups, group)
+		}
+		group.rows = append(group.rows, env)
+	}
+	return groups, nil
+}
+
+// ORDER BY 可以引用输出列的别名或列名，否则在行（或组）上求值
+func (q *mirrorQuery) order(outputs []*mirrorOutputRow, columns []string) error {
+	if len(q.stmt.orderBy) == 0 {
+		return nil
+	}
+	keys := make([][]interface{}, len(outputs))
+	for i, output := range outputs {
+		for _, order := range q.stmt.orderBy {
+			column := -1
+			if ref, ok := order.expr.(*sqlColumnRef); ok && ref.table == "" {
+				for j, name := range columns {
+					if strings.EqualFold(name, ref.name) {
+						column = j
+						break
+					}
+				}
+			}
+			if column >= 0 {
+				keys[i] = append(keys[i], output.values[column])
+				continue
+			}
+			value, err := evalSQL(order.expr, output.env, q.args)
+			if err != nil {
+				return err
+			}
+			keys[i] = append(keys[i], value)
+		}
+	}
+
+	positions := make([]int, len(outputs))
+	for i := range positions {
+		positions[i] = i
+	}
+	sort.SliceStable(positions, func(a, b int) bool {
+		for k, order := range q.stmt.orderBy {
+			x, y := keys[positions[a]][k], keys[positions[b]][k]
+			// NULL 排在最前
+			if x == nil || y == nil {
+				if (x == nil) == (y == nil) {
+					continue
+				}
+				return (x == nil) != order.desc
+			}
+			cmp, _ := sqlCompare(x, y)
+			if cmp == 0 {
+				continue
+			}
+			return (cmp < 0) != order.desc
+		}
+		return false
+	})
+	sorted := make([]*mirrorOutputRow, len(outputs))
+	for i, position := range positions {
+		sorted[i] = outputs[position]
+	}
+	copy(outputs, sorted)
+	return nil
+}
+
+func (q *mirrorQuery) limit(outputs []*mirrorOutputRow) ([]*mirrorOutputRow, error) {
+	if q.stmt.offset != nil {
+		offset, err := q.count(q.stmt.offset)
+		if err != nil {
+			return nil, err
+		}
+		if offset >= len(outputs) {
+			return nil, nil
+		}
+		outputs = outputs[offset:]
+	}
+	if q.stmt.limit != nil {
+		limit, err := q.count(q.stmt.limit)
+		if err != nil {
+			return nil, err
+		}
+		if limit < len(outputs) {
+			outputs = outputs[:limit]
+		}
+	}
+	return outputs, nil
+}
+
+func (q *mirrorQuery) count(expr sqlExpr) (int, error) {
+	value, err := evalSQL(expr, nil, q.args)
+	if err != nil {
+		return 0, err
+	}
+	number, ok := sqlNumber(value)
+	if !ok || number < 0 {
+		return 0, fmt.Errorf("sql: LIMIT and OFFSET must be non-negative numbers, got %v", value)
+	}
+	return int(number), nil
+}
+
+func newMirrorSource(ref sqlTableRef, table *mirrorTable) *mirrorSource {
+	return &mirrorSource{
+		ref:     ref,
+		table:   table,
+		columns: table.columns,
+		types:   table.types,
+		names:   table.names,
+		rows:    table.sorted,
+	}
+}
+
+// 按字段类型将接口返回的单元格转换为查询使用的值
+func mirrorCellValue(fieldType int, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	switch fieldType {
+	case TypeNumber, TypeDateTime, TypeCreatedTime, TypeModifiedTime:
+		if number, ok := sqlNumber(value); ok {
+			return number
+		}
+		if s, ok := value.(string); ok {
+			if number, err := strconv.ParseFloat(s, 64); err == nil {
+				return number
+			}
+		}
+		return nil
+	case TypeCheckbox:
+		b, _ := value.(bool)
+		return b
+	case TypeLink, TypeDuplexLink:
+		return mirrorLinkRecordIds(value)
+	case TypeMultiSelect, TypeUser, TypeCreatedUser, TypeModifiedUser, TypeAttachment, TypeGroupChat:
+		return mirrorTexts(value)
+	case TypeText, TypeUrl, TypePhoneNumber, TypeLocation, TypeAutoSerial, TypeSingleSelect:
+		return strings.Join(mirrorTexts(value), "")
+	}
+	// 公式、查找引用等字段的值结构随引用字段变化
+	if m, ok := value.(map[string]interface{}); ok {
+		if inner, ok := m["value"]; ok {
+			value = inner
+		}
+	}
+	if list, ok := value.([]interface{}); ok && len(list) == 1 {
+		value = list[0]
+	}
+	switch v := value.(type) {
+	case float64, bool, string:
+		return v
+	}
+	texts := mirrorTexts(value)
+	if len(texts) == 1 {
+		return texts[0]
+	}
+	return texts
+}
+
+// 关联字段的值为 {"link_record_ids": [...]}，或记录ID列表，或带 record_ids 的对象列表
+func mirrorLinkRecordIds(value interface{}) []string {
+	var recordIds []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ids, ok := v["link_record_ids"]; ok {
+			return mirrorTexts(ids)
+		}
+		if ids, ok := v["record_ids"]; ok {
+			return mirrorTexts(ids)
+		}
+	case []interface{}:
+		for _, item := range v {
+			switch i := item.(type) {
+			case string:
+				recordIds = append(recordIds, i)
+			case map[string]interface{}:
+				if ids, ok := i["record_ids"]; ok {
+					recordIds = append(recordIds, mirrorTexts(ids)...)
+				} else if id, ok := i["record_id"].(string); ok {
+					recordIds = append(recordIds, id)
+				}
+			}
+		}
+	case string:
+		recordIds = append(recordIds, v)
+	}
+	return recordIds
+}
+
+// 提取单元格中的文本：文本片段取 text，人员、群组取 name，附件取 name，链接取 link，地理位置取 full_address
+func mirrorTexts(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case float64, bool:
+		return []string{sqlString(v)}
+	case []interface{}:
+		var texts []string
+		for _, item := range v {
+			texts = append(texts, mirrorTexts(item)...)
+		}
+		return texts
+	case map[string]interface{}:
+		for _, key := range []string{"text", "name", "full_address", "link", "value"} {
+			if inner, ok := v[key]; ok {
+				return mirrorTexts(inner)
+			}
+		}
+	}
+	return nil
+}
+
+// 输出列名：别名优先，其次为列名或表达式文本
+func sqlExprName(item *sqlSelectItem) string {
+	if item.alias != "" {
+		return item.alias
+	}
+	return sqlExprString(item.expr)
+}
+
+func sqlExprString(expr sqlExpr) string {
+	switch e := expr.(type) {
+	case *sqlColumnRef:
+		return e.name
+	case *sqlLiteral:
+		if s, ok := e.value.(string); ok {
+			return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+		}
+		if e.value == nil {
+			return "NULL"
+		}
+		return sqlString(e.value)
+	case *sqlPlaceholder:
+		return "?"
+	case *sqlCall:
+		if e.star {
+			return e.name + "(*)"
+		}
+		args := make([]string, 0, len(e.args))
+		for _, arg := range e.args {
+			args = append(args, sqlExprString(arg))
+		}
+		prefix := ""
+		if e.distinct {
+			prefix = "DISTINCT "
+		}
+		return e.name + "(" + prefix + strings.Join(args, ", ") + ")"
+	case *sqlBinary:
+		return sqlExprString(e.left) + " " + e.op + " " + sqlExprString(e.right)
+	case *sqlUnary:
+		if e.op == "NOT" {
+			return "NOT " + sqlExprString(e.expr)
+		}
+		return e.op + sqlExprString(e.expr)
+	case *sqlIsNull:
+		if e.not {
+			return sqlExprString(e.expr) + " IS NOT NULL"
+		}
+		return sqlExprString(e.expr) + " IS NULL"
+	case *sqlIn:
+		items := make([]string, 0, len(e.list))
+		for _, item := range e.list {
+			items = append(items, sqlExprString(item))
+		}
+		op := " IN ("
+		if e.not {
+			op = " NOT IN ("
+		}
+		return sqlExprString(e.expr) + op + strings.Join(items, ", ") + ")"
+	}
+	return fmt.Sprint(expr)
+}
@@ -0,0 +1,235 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func newTestMirrorTable(m *Mirror, name string, fields []*AppTableField, records []*AppTableRecord) *mirrorTable {
+	table := &mirrorTable{
+		mirror:  m,
+		name:    name,
+		tableId: "tbl_" + name,
+		records: map[string]*AppTableRecord{},
+		rows:    map[string]map[string]interface{}{},
+	}
+	table.setFields(fields)
+	for _, record := range records {
+		table.PutRecord(context.Background(), table.tableId, record)
+	}
+	table.rebuild()
+	m.tables[name] = table
+	return table
+}
+
+func newTestField(name string, fieldType int) *AppTableField {
+	return NewAppTableFieldBuilder().FieldName(name).Type(fieldType).Build()
+}
+
+func newTestRecord(recordId string, fields map[string]interface{}) *AppTableRecord {
+	return NewAppTableRecordBuilder().RecordId(recordId).Fields(fields).Build()
+}
+
+func newTestMirror() *Mirror {
+	m := &Mirror{tables: map[string]*mirrorTable{}}
+	newTestMirrorTable(m, "customers", []*AppTableField{
+		newTestField("Name", TypeText),
+		newTestField("Region", TypeSingleSelect),
+		newTestField("Tier", TypeNumber),
+	}, []*AppTableRecord{
+		newTestRecord("cus1", map[string]interface{}{"Name": []interface{}{map[string]interface{}{"text": "Alice"}}, "Region": "North", "Tier": 1.0}),
+		newTestRecord("cus2", map[string]interface{}{"Name": "Bob", "Region": "South", "Tier": 2.0}),
+		newTestRecord("cus3", map[string]interface{}{"Name": "Carol", "Region": "North", "Tier": 3.0}),
+	})
+	newTestMirrorTable(m, "orders", []*AppTableField{
+		newTestField("Title", TypeText),
+		newTestField("Amount", TypeNumber),
+		newTestField("Customer", TypeLink),
+		newTestField("Paid", TypeCheckbox),
+		newTestField("Tags", TypeMultiSelect),
+	}, []*AppTableRecord{
+		newTestRecord("ord1", map[string]interface{}{"Title": "A", "Amount": 100.0, "Customer": map[string]interface{}{"link_record_ids": []interface{}{"cus1"}}, "Paid": true, "Tags": []interface{}{"x", "y"}}),
+		newTestRecord("ord2", map[string]interface{}{"Title": "B", "Amount": "50", "Customer": []interface{}{"cus2"}, "Paid": false}),
+		newTestRecord("ord3", map[string]interface{}{"Title": "C", "Amount": 70.0, "Customer": []interface{}{map[string]interface{}{"record_ids": []interface{}{"cus1"}}}, "Paid": true}),
+		newTestRecord("ord4", map[string]interface{}{"Title": "D"}),
+	})
+	return m
+}
+
+func TestMirror_Query(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		args    []interface{}
+		columns []string
+		want    [][]interface{}
+	}{
+		{
+			name:  "where",
+			query: "SELECT Title FROM orders WHERE Amount >= ? AND Paid = TRUE",
+			args:  []interface{}{70},
+			want:  [][]interface{}{{"A"}, {"C"}},
+		},
+		{
+			name:  "where_in_like_or",
+			query: "SELECT Title FROM orders WHERE Title IN ('A', 'B') OR Title LIKE 'D%'",
+			want:  [][]interface{}{{"A"}, {"B"}, {"D"}},
+		},
+		{
+			name:  "where_is_null",
+			query: "SELECT Title FROM orders WHERE Amount IS NULL OR NOT Paid",
+			want:  [][]interface{}{{"B"}, {"D"}},
+		},
+		{
+			name:  "multi_value_contains",
+			query: "SELECT Title FROM orders WHERE Tags = 'y'",
+			want:  [][]interface{}{{"A"}},
+		},
+		{
+			name:    "star",
+			query:   "SELECT * FROM customers WHERE Tier = 2",
+			columns: []string{"record_id", "Name", "Region", "Tier", "created_time", "last_modified_time"},
+			want:    [][]interface{}{{"cus2", "Bob", "South", 2.0, nil, nil}},
+		},
+		{
+			name:  "functions",
+			query: "SELECT UPPER(Name), LENGTH(Name) FROM customers WHERE LOWER(Region) = 'north'",
+			want:  [][]interface{}{{"ALICE", 5.0}, {"CAROL", 5.0}},
+		},
+		{
+			name:    "aggregates_without_group",
+			query:   "SELECT COUNT(*) AS n, COUNT(DISTINCT Customer), SUM(Amount), MIN(Title), MAX(Amount) FROM orders",
+			columns: []string{"n", "COUNT(DISTINCT Customer)", "SUM(Amount)", "MIN(Title)", "MAX(Amount)"},
+			want:    [][]interface{}{{4.0, 2.0, 220.0, "A", 100.0}},
+		},
+		{
+			name:  "group_by_having_order",
+			query: "SELECT Paid, COUNT(*) AS n FROM orders WHERE Title != 'B' GROUP BY Paid HAVING COUNT(*) >= 1 ORDER BY n DESC, Paid",
+			want:  [][]interface{}{{true, 2.0}, {nil, 1.0}},
+		},
+		{
+			name:  "order_nulls_first",
+			query: "SELECT Title FROM orders ORDER BY Amount",
+			want:  [][]interface{}{{"D"}, {"B"}, {"C"}, {"A"}},
+		},
+		{
+			name:  "order_desc_limit_offset",
+			query: "SELECT Title FROM orders ORDER BY Amount DESC LIMIT 2 OFFSET 1",
+			want:  [][]interface{}{{"C"}, {"B"}},
+		},
+		{
+			name:  "offset_past_end",
+			query: "SELECT Title FROM orders LIMIT 10 OFFSET 10",
+			want:  nil,
+		},
+		{
+			name:  "link_join",
+			query: "SELECT o.Title, c.Name FROM orders o JOIN customers c ON o.Customer = c.record_id ORDER BY o.Title",
+			want:  [][]interface{}{{"A", "Alice"}, {"B", "Bob"}, {"C", "Alice"}},
+		},
+		{
+			name:  "link_left_join",
+			query: "SELECT o.Title, c.Name FROM orders AS o LEFT JOIN customers AS c ON o.Customer = c.record_id WHERE o.Title > 'B'",
+			want:  [][]interface{}{{"C", "Alice"}, {"D", nil}},
+		},
+		{
+			name:  "link_join_group_having",
+			query: "SELECT c.Region, COUNT(*) AS n, SUM(o.Amount) AS total FROM orders o JOIN customers c ON o.Customer = c.record_id GROUP BY c.Region HAVING SUM(o.Amount) > ? ORDER BY total DESC",
+			args:  []interface{}{60},
+			want:  [][]interface{}{{"North", 2.0, 170.0}},
+		},
+		{
+			name:  "placeholders",
+			query: "SELECT Title FROM orders WHERE Customer = ? AND Amount > ? LIMIT ?",
+			args:  []interface{}{"cus1", int64(80), 5},
+			want:  [][]interface{}{{"A"}},
+		},
+	}
+	m := newTestMirror()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := m.Query(context.Background(), tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("Query() error = %v", err)
+			}
+			if tt.columns != nil && !reflect.DeepEqual(result.Columns, tt.columns) {
+				t.Errorf("Query() columns = %v, want %v", result.Columns, tt.columns)
+			}
+			if !reflect.DeepEqual(result.Rows, tt.want) {
+				t.Errorf("Query() rows = %v, want %v", result.Rows, tt.want)
+			}
+		})
+	}
+}
+
+func TestMirror_QueryErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		args  []interface{}
+	}{
+		{name: "unknown_table", query: "SELECT * FROM missing"},
+		{name: "unknown_column", query: "SELECT Missing FROM orders"},
+		{name: "ambiguous_column", query: "SELECT record_id FROM orders o JOIN customers c ON o.Customer = c.record_id"},
+		{name: "missing_argument", query: "SELECT Title FROM orders WHERE Amount > ?"},
+		{name: "negative_limit", query: "SELECT Title FROM orders LIMIT ?", args: []interface{}{-1}},
+		{name: "unsupported_function", query: "SELECT NOW() FROM orders"},
+		{name: "not_select", query: "DELETE FROM orders WHERE record_id = 'ord1'"},
+	}
+	m := newTestMirror()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := m.Query(context.Background(), tt.query, tt.args...); err == nil {
+				t.Fatalf("Query(%q) expected an error", tt.query)
+			}
+		})
+	}
+}
+
+func TestMirrorTable_Store(t *testing.T) {
+	ctx := context.Background()
+	m := newTestMirror()
+	table := m.tables["orders"]
+
+	table.PutRecord(ctx, table.tableId, newTestRecord("ord2", map[string]interface{}{"Title": "B2", "Amount": 55.0}))
+	table.DeleteRecord(ctx, table.tableId, "ord4")
+	table.rebuild()
+	result, err := m.Query(ctx, "SELECT record_id, Title FROM orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]interface{}{{"ord1", "A"}, {"ord2", "B2"}, {"ord3", "C"}}
+	if !reflect.DeepEqual(result.Rows, want) {
+		t.Fatalf("after put and delete rows = %v, want %v", result.Rows, want)
+	}
+
+	// 字段类型变化后，已有的行按新类型重新转换
+	table.setFields([]*AppTableField{newTestField("Title", TypeText), newTestField("Amount", TypeText)})
+	table.rebuild()
+	if result, err = m.Query(ctx, "SELECT Amount FROM orders WHERE record_id = 'ord2'"); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]interface{}{{"55"}}; !reflect.DeepEqual(result.Rows, want) {
+		t.Fatalf("after field change rows = %v, want %v", result.Rows, want)
+	}
+
+	state := &SyncState{Watermark: 42, Rounds: 3}
+	table.SaveState(ctx, table.tableId, state)
+	state.Rounds = 4
+	if got, _ := table.LoadState(ctx, table.tableId); got == nil || got.Watermark != 42 || got.Rounds != 3 {
+		t.Fatalf("LoadState() = %+v, want the saved copy", got)
+	}
+}
@@ -0,0 +1,288 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	RecordScopeNone     = "none"     // 不可访问记录
+	RecordScopeAll      = "all"      // 全部记录
+	RecordScopeFiltered = "filtered" // 仅满足记录筛选条件的记录
+)
+
+// PermissionEntry 一个协作者在一个字段上的有效权限
+type PermissionEntry struct {
+	MemberId          string   `json:"member_id"`           // 协作者ID，按 open_id、union_id、user_id、chat_id、open_department_id、department_id 的顺序选取
+	MemberName        string   `json:"member_name"`         // 协作者名字
+	MemberType        string   `json:"member_type"`         // 协作者类型
+	Roles             []string `json:"roles"`               // 授予该权限的角色
+	Table             string   `json:"table"`               // 数据表名
+	Field             string   `json:"field"`               // 字段名
+	CanRead           bool     `json:"can_read"`            // 是否可阅读
+	CanEdit           bool     `json:"can_edit"`            // 是否可编辑
+	RecordScope       string   `json:"record_scope"`        // 可访问的记录范围，取值见 RecordScope* 常量
+	AllowAddRecord    bool     `json:"allow_add_record"`    // 是否可新增记录
+	AllowDeleteRecord bool     `json:"allow_delete_record"` // 是否可删除记录
+}
+
+// PermissionMatrix 多维表格的有效权限矩阵
+//
+// - 一个协作者属于多个角色时，权限取各角色的并集
+type PermissionMatrix struct {
+	Entries []*PermissionEntry
+
+	members map[string]string           // 协作者的任意ID -> MemberId
+	index   map[string]*PermissionEntry // MemberId + 数据表 + 字段 -> 权限
+}
+
+// 计算开启高级权限的多维表格中，每个自定义角色协作者对每个字段的有效权限
+//
+// - 综合角色的数据表权限、字段权限、记录筛选条件以及新增、删除记录权限
+//
+// - 数据表权限为可编辑且未单独设置字段权限的字段视为可编辑
+func (b *BaseService) AnalyzePermissions(ctx context.Context, options ...larkcore.RequestOptionFunc) (*PermissionMatrix, error) {
+	tables, err := b.AppTable.ListAll(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string][]*AppTableField{}
+	for _, table := range tables {
+		if table.TableId == nil {
+			continue
+		}
+		if fields[*table.TableId], err = b.AppTableField.ListAll(ctx, *table.TableId, options...); err != nil {
+			return nil, err
+		}
+	}
+	roles, err := b.AppRole.ListAll(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &PermissionMatrix{members: map[string]string{}, index: map[string]*PermissionEntry{}}
+	for _, role := range roles {
+		if role.RoleId == nil {
+			continue
+		}
+		members, err := b.AppRoleMember.ListAll(ctx, *role.RoleId, options...)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			memberId := matrix.addMember(member)
+			if memberId == "" {
+				continue
+			}
+			for _, table := range tables {
+				if table.TableId == nil || table.Name == nil {
+					continue
+				}
+				tableRole := findTableRole(role, table)
+				for _, field := range fields[*table.TableId] {
+					if field.FieldName == nil {
+						continue
+					}
+					matrix.grant(memberId, member, stringValue(role.RoleName), *table.Name, *field.FieldName, tableRole)
+				}
+			}
+		}
+	}
+	sort.Slice(matrix.Entries, func(i, j int) bool {
+		x, y := matrix.Entries[i], matrix.Entries[j]
+		if x.MemberId != y.MemberId {
+			return x.MemberId < y.MemberId
+		}
+		if x.Table != y.Table {
+			return x.Table < y.Table
+		}
+		return x.Field < y.Field
+	})
+	return matrix, nil
+}
+
+// 协作者是否可编辑指定字段，memberId 可以是协作者的任意一种ID
+func (m *PermissionMatrix) CanEdit(memberId string, table string, field string) bool {
+	entry := m.Lookup(memberId, table, field)
+	return entry != nil && entry.CanEdit
+}
+
+// 协作者是否可阅读指定字段，memberId 可以是协作者的任意一种ID
+func (m *PermissionMatrix) CanRead(memberId string, table string, field string) bool {
+	entry := m.Lookup(memberId, table, field)
+	return entry != nil && entry.CanRead
+}
+
+// 查询协作者在指定字段上的有效权限，不属于任何自定义角色时返回 nil
+func (m *PermissionMatrix) Lookup(memberId string, table string, field string) *PermissionEntry {
+	key, ok := m.members[memberId]
+	if !ok {
+		return nil
+	}
+	return m.index[permissionKey(key, table, field)]
+}
+
+var permissionCSVHeader = []string{"member_id", "member_name", "member_type", "roles", "table", "field", "can_read", "can_edit", "record_scope", "allow_add_record", "allow_delete_record"}
+
+// 以 CSV 输出权限矩阵
+func (m *PermissionMatrix) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(permissionCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range m.Entries {
+		if err := writer.Write(entry.row()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// 以 JSON 数组输出权限矩阵
+func (m *PermissionMatrix) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	entries := m.Entries
+	if entries == nil {
+		entries = []*PermissionEntry{}
+	}
+	return encoder.Encode(entries)
+}
+
+// 以 Markdown 表格输出权限矩阵
+func (m *PermissionMatrix) WriteMarkdown(w io.Writer) error {
+	lines := []string{
+		"| " + strings.Join(permissionCSVHeader, " | ") + " |",
+		"|" + strings.Repeat(" --- |", len(permissionCSVHeader)),
+	}
+	for _, entry := range m.Entries {
+		row := entry.row()
+		for i, cell := range row {
+			row[i] = strings.ReplaceAll(cell, "|", "\\|")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func (e *PermissionEntry) row() []string {
+	return []string{
+		e.MemberId, e.MemberName, e.MemberType, strings.Join(e.Roles, ","), e.Table, e.Field,
+		fmt.Sprint(e.CanRead), fmt.Sprint(e.CanEdit), e.RecordScope, fmt.Sprint(e.AllowAddRecord), fmt.Sprint(e.AllowDeleteRecord),
+	}
+}
+
+// 登记协作者的全部ID，返回其 MemberId
+func (m *PermissionMatrix) addMember(member *AppRoleMember) string {
+	var ids []string
+	for _, idType := range []string{MemberIdTypeOpenID, MemberIdTypeUnionID, MemberIdTypeUserID, MemberIdTypeChatID, MemberIdTypeOpenDepartmentID, MemberIdTypeDepartmentID} {
+		if id := roleMemberId(member, idType); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if member.MemberId != nil && *member.MemberId != "" {
+		ids = append(ids, *member.MemberId)
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	// 已通过其它角色登记过的协作者沿用原有的 MemberId
+	memberId := ids[0]
+	for _, id := range ids {
+		if existing, ok := m.members[id]; ok {
+			memberId = existing
+			break
+		}
+	}
+	for _, id := range ids {
+		m.members[id] = memberId
+	}
+	return memberId
+}
+
+// 将一个角色在字段上的权限合并到矩阵
+func (m *PermissionMatrix) grant(memberId string, member *AppRoleMember, roleName string, table string, field string, tableRole *AppRoleTableRoleDetail) {
+	key := permissionKey(memberId, table, field)
+	entry, ok := m.index[key]
+	if !ok {
+		entry = &PermissionEntry{
+			MemberId:    memberId,
+			MemberName:  stringValue(member.MemberName),
+			MemberType:  stringValue(member.MemberType),
+			Table:       table,
+			Field:       field,
+			RecordScope: RecordScopeNone,
+		}
+		m.index[key] = entry
+		m.Entries = append(m.Entries, entry)
+	}
+	if tableRole == nil {
+		return
+	}
+
+	tablePerm := intValue(tableRole.TablePerm)
+	canRead, canEdit := tablePerm >= TablePermRead, tablePerm >= TablePermEdit
+	if tablePerm == TablePermEdit && tableRole.FieldPerm != nil {
+		if fieldPerm, ok := tableRole.FieldPerm[field]; ok {
+			canRead, canEdit = fieldPerm >= FieldPermRead, fieldPerm >= FieldPermEdit
+		}
+	}
+	if !canRead {
+		return
+	}
+
+	entry.CanRead = true
+	entry.CanEdit = entry.CanEdit || canEdit
+	entry.Roles = append(entry.Roles, roleName)
+	scope := RecordScopeAll
+	if tablePerm != TablePermManage && tableRole.RecRule != nil && len(tableRole.RecRule.Conditions) > 0 {
+		scope = RecordScopeFiltered
+	}
+	if entry.RecordScope != RecordScopeAll {
+		entry.RecordScope = scope
+	}
+	if tablePerm == TablePermManage {
+		entry.AllowAddRecord, entry.AllowDeleteRecord = true, true
+	} else if tablePerm == TablePermEdit {
+		entry.AllowAddRecord = entry.AllowAddRecord || boolValue(tableRole.AllowAddRecord)
+		entry.AllowDeleteRecord = entry.AllowDeleteRecord || boolValue(tableRole.AllowDeleteRecord)
+	}
+}
+
+// 角色中对应数据表的权限，按 table_id 或数据表名匹配
+func findTableRole(role *AppRoleDetail, table *AppTable) *AppRoleTableRoleDetail {
+	for _, tableRole := range role.TableRoles {
+		if tableRole.TableId != nil && *tableRole.TableId == *table.TableId {
+			return tableRole
+		}
+		if tableRole.TableName != nil && *tableRole.TableName == *table.Name {
+			return tableRole
+		}
+	}
+	return nil
+}
+
+func permissionKey(memberId string, table string, field string) string {
+	return memberId + "\x00" + table + "\x00" + field
+}
@@ -0,0 +1,248 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func newTestPermissionMatrix() *PermissionMatrix {
+	return &PermissionMatrix{members: map[string]string{}, index: map[string]*PermissionEntry{}}
+}
+
+func newTestRoleMember(openId string, unionId string, name string) *AppRoleMember {
+	return NewAppRoleMemberBuilder().OpenId(openId).UnionId(unionId).MemberName(name).MemberType("user").Build()
+}
+
+func newTestTableRoleDetail(perm int, fieldPerm map[string]int, allowAdd bool, allowDelete bool, recRule *AppRoleTableRoleRecRule) *AppRoleTableRoleDetail {
+	builder := NewAppRoleTableRoleBuilder().TableName("orders").TablePerm(perm).AllowAddRecord(allowAdd).AllowDeleteRecord(allowDelete)
+	if recRule != nil {
+		builder.RecRule(recRule)
+	}
+	return &AppRoleTableRoleDetail{AppRoleTableRole: builder.Build(), FieldPerm: fieldPerm}
+}
+
+func TestPermissionMatrix_Grant(t *testing.T) {
+	recRule := newTestRecRule(RecRuleConjunctionAnd, newTestRecRuleCondition("Owner", "opt_a"))
+	tests := []struct {
+		name       string
+		tableRoles []*AppRoleTableRoleDetail // 依次授予的角色权限
+		want       PermissionEntry
+	}{
+		{
+			name:       "no_table_role",
+			tableRoles: []*AppRoleTableRoleDetail{nil},
+			want:       PermissionEntry{RecordScope: RecordScopeNone},
+		},
+		{
+			name:       "no_permission",
+			tableRoles: []*AppRoleTableRoleDetail{newTestTableRoleDetail(TablePermNone, nil, false, false, nil)},
+			want:       PermissionEntry{RecordScope: RecordScopeNone},
+		},
+		{
+			name:       "read",
+			tableRoles: []*AppRoleTableRoleDetail{newTestTableRoleDetail(TablePermRead, nil, false, false, nil)},
+			want:       PermissionEntry{Roles: []string{"role0"}, CanRead: true, RecordScope: RecordScopeAll},
+		},
+		{
+			name:       "edit_with_record_rule",
+			tableRoles: []*AppRoleTableRoleDetail{newTestTableRoleDetail(TablePermEdit, nil, true, false, recRule)},
+			want:       PermissionEntry{Roles: []string{"role0"}, CanRead: true, CanEdit: true, RecordScope: RecordScopeFiltered, AllowAddRecord: true},
+		},
+		{
+			name:       "edit_with_read_only_field",
+			tableRoles: []*AppRoleTableRoleDetail{newTestTableRoleDetail(TablePermEdit, map[string]int{"Amount": FieldPermRead}, false, true, nil)},
+			want:       PermissionEntry{Roles: []string{"role0"}, CanRead: true, RecordScope: RecordScopeAll, AllowDeleteRecord: true},
+		},
+		{
+			name:       "edit_with_hidden_field",
+			tableRoles: []*AppRoleTableRoleDetail{newTestTableRoleDetail(TablePermEdit, map[string]int{"Amount": 0}, true, true, nil)},
+			want:       PermissionEntry{RecordScope: RecordScopeNone},
+		},
+		{
+			name:       "field_perm_ignored_without_edit",
+			tableRoles: []*AppRoleTableRoleDetail{newTestTableRoleDetail(TablePermRead, map[string]int{"Amount": FieldPermEdit}, true, true, nil)},
+			want:       PermissionEntry{Roles: []string{"role0"}, CanRead: true, RecordScope: RecordScopeAll},
+		},
+		{
+			name:       "manage_ignores_record_rule",
+			tableRoles: []*AppRoleTableRoleDetail{newTestTableRoleDetail(TablePermManage, nil, false, false, recRule)},
+			want:       PermissionEntry{Roles: []string{"role0"}, CanRead: true, CanEdit: true, RecordScope: RecordScopeAll, AllowAddRecord: true, AllowDeleteRecord: true},
+		},
+		{
+			name: "union_of_roles",
+			tableRoles: []*AppRoleTableRoleDetail{
+				newTestTableRoleDetail(TablePermRead, nil, false, false, nil),
+				newTestTableRoleDetail(TablePermEdit, nil, false, true, recRule),
+			},
+			want: PermissionEntry{Roles: []string{"role0", "role1"}, CanRead: true, CanEdit: true, RecordScope: RecordScopeAll, AllowDeleteRecord: true},
+		},
+		{
+			name: "filtered_then_all",
+			tableRoles: []*AppRoleTableRoleDetail{
+				newTestTableRoleDetail(TablePermEdit, nil, false, false, recRule),
+				newTestTableRoleDetail(TablePermRead, nil, false, false, nil),
+			},
+			want: PermissionEntry{Roles: []string{"role0", "role1"}, CanRead: true, CanEdit: true, RecordScope: RecordScopeAll},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix := newTestPermissionMatrix()
+			member := newTestRoleMember("ou_1", "on_1", "Alice")
+			memberId := matrix.addMember(member)
+			for i, tableRole := range tt.tableRoles {
+				matrix.grant(memberId, member, fmt.Sprintf("role%d", i), "orders", "Amount", tableRole)
+			}
+			want := tt.want
+			want.MemberId, want.MemberName, want.MemberType, want.Table, want.Field = "ou_1", "Alice", "user", "orders", "Amount"
+			got := matrix.Lookup("on_1", "orders", "Amount")
+			if got == nil || !reflect.DeepEqual(*got, want) {
+				t.Fatalf("Lookup() = %+v, want %+v", got, want)
+			}
+			if matrix.CanRead("ou_1", "orders", "Amount") != want.CanRead || matrix.CanEdit("on_1", "orders", "Amount") != want.CanEdit {
+				t.Errorf("CanRead/CanEdit disagree with Lookup() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestPermissionMatrix_Lookup(t *testing.T) {
+	matrix := newTestPermissionMatrix()
+	tableRole := newTestTableRoleDetail(TablePermEdit, nil, false, false, nil)
+	first := newTestRoleMember("ou_1", "", "Alice")
+	matrix.grant(matrix.addMember(first), first, "sales", "orders", "Amount", tableRole)
+	// 另一个角色返回的同一协作者带有更多ID，沿用已登记的 MemberId
+	second := newTestRoleMember("ou_1", "on_1", "Alice")
+	matrix.grant(matrix.addMember(second), second, "audit", "orders", "Amount", tableRole)
+
+	tests := []struct {
+		name     string
+		memberId string
+		table    string
+		field    string
+		wantEdit bool
+	}{
+		{name: "open_id", memberId: "ou_1", table: "orders", field: "Amount", wantEdit: true},
+		{name: "union_id", memberId: "on_1", table: "orders", field: "Amount", wantEdit: true},
+		{name: "unknown_member", memberId: "ou_2", table: "orders", field: "Amount"},
+		{name: "unknown_field", memberId: "ou_1", table: "orders", field: "Status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matrix.CanEdit(tt.memberId, tt.table, tt.field); got != tt.wantEdit {
+				t.Errorf("CanEdit() = %t, want %t", got, tt.wantEdit)
+			}
+		})
+	}
+	if len(matrix.Entries) != 1 || !reflect.DeepEqual(matrix.Entries[0].Roles, []string{"sales", "audit"}) {
+		t.Errorf("Entries = %+v, want one entry granted by both roles", matrix.Entries)
+	}
+}
+
+func TestFindTableRole(t *testing.T) {
+	byId := &AppRoleTableRoleDetail{AppRoleTableRole: NewAppRoleTableRoleBuilder().TableId("tbl1").TableName("old name").Build()}
+	byName := &AppRoleTableRoleDetail{AppRoleTableRole: NewAppRoleTableRoleBuilder().TableName("customers").Build()}
+	role := &AppRoleDetail{AppRole: NewAppRoleBuilder().Build(), TableRoles: []*AppRoleTableRoleDetail{byId, byName}}
+	tests := []struct {
+		name  string
+		table *AppTable
+		want  *AppRoleTableRoleDetail
+	}{
+		{name: "table_id", table: NewAppTableBuilder().TableId("tbl1").Name("orders").Build(), want: byId},
+		{name: "table_name", table: NewAppTableBuilder().TableId("tbl2").Name("customers").Build(), want: byName},
+		{name: "not_found", table: NewAppTableBuilder().TableId("tbl3").Name("products").Build()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findTableRole(role, tt.table); got != tt.want {
+				t.Errorf("findTableRole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionMatrix_Write(t *testing.T) {
+	matrix := &PermissionMatrix{Entries: []*PermissionEntry{{
+		MemberId:       "ou_1",
+		MemberName:     "A|B",
+		MemberType:     "user",
+		Roles:          []string{"sales", "audit"},
+		Table:          "orders",
+		Field:          "Amount",
+		CanRead:        true,
+		RecordScope:    RecordScopeFiltered,
+		AllowAddRecord: true,
+	}}}
+	tests := []struct {
+		name  string
+		write func(*PermissionMatrix, *bytes.Buffer) error
+		want  string
+	}{
+		{
+			name:  "csv",
+			write: func(m *PermissionMatrix, buf *bytes.Buffer) error { return m.WriteCSV(buf) },
+			want: "member_id,member_name,member_type,roles,table,field,can_read,can_edit,record_scope,allow_add_record,allow_delete_record\n" +
+				"ou_1,A|B,user,\"sales,audit\",orders,Amount,true,false,filtered,true,false\n",
+		},
+		{
+			name:  "markdown",
+			write: func(m *PermissionMatrix, buf *bytes.Buffer) error { return m.WriteMarkdown(buf) },
+			want: "| member_id | member_name | member_type | roles | table | field | can_read | can_edit | record_scope | allow_add_record | allow_delete_record |\n" +
+				"| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n" +
+				"| ou_1 | A\\|B | user | sales,audit | orders | Amount | true | false | filtered | true | false |\n",
+		},
+		{
+			name:  "json",
+			write: func(m *PermissionMatrix, buf *bytes.Buffer) error { return m.WriteJSON(buf) },
+			want: `[
+  {
+    "member_id": "ou_1",
+    "member_name": "A|B",
+    "member_type": "user",
+    "roles": [
+      "sales",
+      "audit"
+    ],
+    "table": "orders",
+    "field": "Amount",
+    "can_read": true,
+    "can_edit": false,
+    "record_scope": "filtered",
+    "allow_add_record": true,
+    "allow_delete_record": false
+  }
+]
+`,
+		},
+		{
+			name:  "empty_json",
+			write: func(m *PermissionMatrix, buf *bytes.Buffer) error { return (&PermissionMatrix{}).WriteJSON(buf) },
+			want:  "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.write(matrix, &buf); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
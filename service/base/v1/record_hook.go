@@ -0,0 +1,73 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// RecordWriteHook 在新增、更新记录的请求发出前调用
+//
+// - appToken 为请求路径中的多维表格 token，请求未指定时为 Config 中的 AppToken
+//
+// - records 为请求中的全部记录，hook 可以就地修改记录的字段
+//
+// - 返回错误时不发出请求，AppTableRecord 的 Create、Update、BatchCreate、BatchUpdate 直接返回该错误
+type RecordWriteHook func(ctx context.Context, appToken string, tableId string, records []*AppTableRecord) error
+
+// 注册记录写入 hook，按注册顺序调用，在全部 UseRecordFillHook 注册的 hook 之后调用
+//
+// - 需要在发起请求前完成注册，注册过程不是并发安全的
+func (b *BaseService) UseRecordWriteHook(hook RecordWriteHook) {
+	b.recordWriteHooks = append(b.recordWriteHooks, hook)
+}
+
+// 注册补全记录的 hook，例如补充选项，按注册顺序调用，总是先于 UseRecordWriteHook 注册的校验类 hook
+//
+// - 需要在发起请求前完成注册，注册过程不是并发安全的
+func (b *BaseService) UseRecordFillHook(hook RecordWriteHook) {
+	b.recordFillHooks = append(b.recordFillHooks, hook)
+}
+
+func (b *BaseService) beforeRecordWrite(ctx context.Context, apiReq *larkcore.ApiReq) error {
+	if len(b.recordFillHooks) == 0 && len(b.recordWriteHooks) == 0 {
+		return nil
+	}
+	var records []*AppTableRecord
+	switch body := apiReq.Body.(type) {
+	case *AppTableRecord:
+		records = []*AppTableRecord{body}
+	case *BatchCreateAppTableRecordReqBody:
+		records = body.Records
+	case *BatchUpdateAppTableRecordReqBody:
+		records = body.Records
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	appToken := apiReq.PathParams.Get("app_token")
+	if appToken == "" {
+		appToken = b.config.AppToken
+	}
+	tableId := apiReq.PathParams.Get("table_id")
+	for _, hooks := range [][]RecordWriteHook{b.recordFillHooks, b.recordWriteHooks} {
+		for _, hook := range hooks {
+			if err := hook(ctx, appToken, tableId, records); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	recordValidatorSchemaTTL     = 5 * time.Minute
+	recordValidatorRefreshMinAge = 10 * time.Second // 字段定义至少缓存这么久才会因校验未通过而重新获取
+)
+
+const (
+	violationFieldNotFound = "field not found"
+	violationUnknownOption = "unknown option"
+)
+
+// FieldViolation 记录中一个字段值不符合字段定义
+type FieldViolation struct {
+	Index     int    // 记录在本次写入中的序号
+	RecordId  string // 记录ID，新增记录为空
+	FieldName string // 字段名
+	Reason    string // 原因
+
+	stale bool // 可能由缓存的字段定义过期导致，例如字段或选项是刚新增的
+}
+
+func (v *FieldViolation) Error() string {
+	record := fmt.Sprintf("record #%d", v.Index)
+	if v.RecordId != "" {
+		record = fmt.Sprintf("record %s", v.RecordId)
+	}
+	return fmt.Sprintf("%s field %s: %s", record, v.FieldName, v.Reason)
+}
+
+// RecordValidationError 本地校验未通过，请求未发出
+type RecordValidationError struct {
+	TableId    string
+	Violations []*FieldViolation
+}
+
+func (e *RecordValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Error())
+	}
+	return fmt.Sprintf("table %s: %d invalid field values: %s", e.TableId, len(e.Violations), strings.Join(messages, "; "))
+}
+
+// RecordValidator 在写入前按字段定义校验记录
+//
+// - 校验字段是否存在、只读字段、值的类型、单选多选的选项、进度评分等字段的范围，以及人员和关联字段的单选多选
+//
+// - 字段定义按数据表缓存五分钟，与同一 BaseService 的 SelectOptionCreator 共享；修改字段后也可调用 Invalidate 立即丢弃缓存
+type RecordValidator struct {
+	service *BaseService
+	schemas *tableSchemaCache
+}
+
+// 创建记录校验器
+func (b *BaseService) NewRecordValidator(options ...larkcore.RequestOptionFunc) *RecordValidator {
+	return &RecordValidator{service: b, schemas: newTableSchemaCache(b, recordValidatorSchemaTTL, options)}
+}
+
+// 校验记录，返回全部不符合字段定义的字段值；获取字段定义失败时返回错误
+//
+// - 出现字段不存在、选项不存在等可能由缓存过期导致的问题时，重新获取一次字段定义再校验；
+// 同一数据表的字段定义获取不足 10 秒时不会重新获取，避免错误的写入反复请求字段列表
+func (v *RecordValidator) Validate(ctx context.Context, appToken string, tableId string, records []*AppTableRecord) ([]*FieldViolation, error) {
+	schema, err := v.schemas.get(ctx, appToken, tableId)
+	if err != nil {
+		return nil, err
+	}
+	violations := ValidateRecords(schema.fields, records)
+	stale := false
+	for _, violation := range violations {
+		stale = stale || violation.stale
+	}
+	if !stale {
+		return violations, nil
+	}
+	refreshed, err := v.schemas.refresh(ctx, appToken, tableId, recordValidatorRefreshMinAge)
+	if err != nil {
+		return nil, err
+	}
+	if refreshed == schema {
+		return violations, nil
+	}
+	return ValidateRecords(refreshed.fields, records), nil
+}
+
+// 丢弃数据表的字段定义缓存
+func (v *RecordValidator) Invalidate(appToken string, tableId string) {
+	v.schemas.invalidate(appToken, tableId)
+}
+
+// 将校验注册为创建校验器的 BaseService 的记录写入 hook，在 SelectOptionCreator 等补全类 hook 之后调用
+func (v *RecordValidator) Register() {
+	v.service.UseRecordWriteHook(v.Hook())
+}
+
+// 返回可通过 BaseService.UseRecordWriteHook 注册的 hook，校验未通过时返回 *RecordValidationError
+func (v *RecordValidator) Hook() RecordWriteHook {
+	return func(ctx context.Context, appToken string, tableId string, records []*AppTableRecord) error {
+		violations, err := v.Validate(ctx, appToken, tableId, records)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			return &RecordValidationError{TableId: tableId, Violations: violations}
+		}
+		return nil
+	}
+}
+
+// 使用已获取的字段定义校验记录
+func ValidateRecords(fields []*AppTableField, records []*AppTableRecord) []*FieldViolation {
+	byName := map[string]*AppTableField{}
+	for _, field := range fields {
+		if field.FieldName != nil {
+			byName[*field.FieldName] = field
+		}
+	}
+	var violations []*FieldViolation
+	for i, record := range records {
+		if record == nil {
+			continue
+		}
+		names := make([]string, 0, len(record.Fields))
+		for name := range record.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value := record.Fields[name]
+			var reason string
+			if field, ok := byName[name]; !ok || field.Type == nil {
+				reason = violationFieldNotFound
+			} else {
+				reason = validateFieldValue(field, value)
+			}
+			if reason != "" {
+				violations = append(violations, &FieldViolation{
+					Index:     i,
+					RecordId:  stringValue(record.RecordId),
+					FieldName: name,
+					Reason:    reason,
+					stale:     reason == violationFieldNotFound || strings.HasPrefix(reason, violationUnknownOption),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// 校验单个字段值，返回不符合的原因；空值表示清空字段，总是有效
+func validateFieldValue(field *AppTableField, value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	switch *field.Type {
+	case TypeFormula, TypeCreatedTime, TypeModifiedTime, TypeCreatedUser, TypeModifiedUser, TypeAutoSerial:
+		return "field is read only"
+	}
+	// 统一为 JSON 解码后的结构，兼容 []string、int 等 Go 类型
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("value cannot be encoded: %v", err)
+	}
+	var v interface{}
+	_ = json.Unmarshal(data, &v)
+	if v == nil {
+		return ""
+	}
+
+	switch *field.Type {
+	case TypeText:
+		if _, ok := v.(string); ok {
+			return ""
+		}
+		if segments, ok := v.([]interface{}); ok {
+			for _, segment := range segments {
+				if m, ok := segment.(map[string]interface{}); !ok || m["text"] == nil {
+					return "text segments must be objects with text"
+				}
+			}
+			return ""
+		}
+		return "must be a string"
+	case TypePhoneNumber, TypeLocation:
+		if _, ok := v.(string); !ok {
+			return "must be a string"
+		}
+	case TypeNumber:
+		number, ok := v.(float64)
+		if !ok {
+			return "must be a number"
+		}
+		return validateNumberRange(field, number)
+	case TypeDateTime:
+		if _, ok := v.(float64); !ok {
+			return "must be a unix timestamp in milliseconds"
+		}
+	case TypeCheckbox:
+		if _, ok := v.(bool); !ok {
+			return "must be a bool"
+		}
+	case TypeSingleSelect:
+		name, ok := v.(string)
+		if !ok {
+			return "must be an option name"
+		}
+		if _, err := fieldOptionId(field, name); err != nil {
+			return fmt.Sprintf("%s %q", violationUnknownOption, name)
+		}
+	case TypeMultiSelect:
+		names, ok := validateStringList(v)
+		if !ok {
+			return "must be a list of option names"
+		}
+		for _, name := range names {
+			if _, err := fieldOptionId(field, name); err != nil {
+				return fmt.Sprintf("%s %q", violationUnknownOption, name)
+			}
+		}
+	case TypeUser, TypeGroupChat:
+		items, ok := v.([]interface{})
+		if !ok {
+			return "must be a list of objects with id"
+		}
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); !ok || m["id"] == nil {
+				return "must be a list of objects with id"
+			}
+		}
+		if *field.Type == TypeUser && len(items) > 1 && !fieldMultiple(field) {
+			return "only one person is allowed"
+		}
+	case TypeLink, TypeDuplexLink:
+		recordIds, ok := validateStringList(v)
+		if !ok {
+			return "must be a list of record ids"
+		}
+		if len(recordIds) > 1 && !fieldMultiple(field) {
+			return "only one linked record is allowed"
+		}
+	case TypeUrl:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return "must be an object with link and text"
+		}
+		if _, ok := m["link"].(string); !ok {
+			return "link is required"
+		}
+	case TypeAttachment:
+		items, ok := v.([]interface{})
+		if !ok {
+			return "must be a list of objects with file_token"
+		}
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); !ok || m[attachmentKeyFileToken] == nil {
+				return "must be a list of objects with file_token"
+			}
+		}
+	}
+	return ""
+}
+
+// 进度、评分等字段的取值范围，评分只能为整数
+func validateNumberRange(field *AppTableField, number float64) string {
+	if field.Property == nil {
+		return ""
+	}
+	if field.Property.Min != nil && number < *field.Property.Min {
+		return fmt.Sprintf("must be at least %v", *field.Property.Min)
+	}
+	if field.Property.Max != nil && number > *field.Property.Max {
+		return fmt.Sprintf("must be at most %v", *field.Property.Max)
+	}
+	if field.Property.Rating != nil && number != math.Trunc(number) {
+		return "rating must be an integer"
+	}
+	return ""
+}
+
+func validateStringList(v interface{}) ([]string, bool) {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		list = append(list, s)
+	}
+	return list, true
+}
+
+// 人员、关联字段是否允许多个值，未设置时按允许处理
+func fieldMultiple(field *AppTableField) bool {
+	return field.Property == nil || field.Property.Multiple == nil || *field.Property.Multiple
+}
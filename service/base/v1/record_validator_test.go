@@ -0,0 +1,206 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestValidateRecords(t *testing.T) {
+	options := []*AppTableFieldPropertyOption{
+		NewAppTableFieldPropertyOptionBuilder().Id("opt1").Name("Open").Build(),
+		NewAppTableFieldPropertyOptionBuilder().Id("opt2").Name("Closed").Build(),
+	}
+	fields := []*AppTableField{
+		newTestField("Title", TypeText),
+		NewAppTableFieldBuilder().FieldName("Progress").Type(TypeNumber).Property(NewAppTableFieldPropertyBuilder().Min(0).Max(1).Build()).Build(),
+		NewAppTableFieldBuilder().FieldName("Status").Type(TypeSingleSelect).Property(NewAppTableFieldPropertyBuilder().Options(options).Build()).Build(),
+		NewAppTableFieldBuilder().FieldName("Labels").Type(TypeMultiSelect).Property(NewAppTableFieldPropertyBuilder().Options(options).Build()).Build(),
+		NewAppTableFieldBuilder().FieldName("Owner").Type(TypeUser).Property(NewAppTableFieldPropertyBuilder().Multiple(false).Build()).Build(),
+		newTestField("Parent", TypeLink),
+		newTestField("Due", TypeDateTime),
+		newTestField("Done", TypeCheckbox),
+		newTestField("Link", TypeUrl),
+		newTestField("Files", TypeAttachment),
+		newTestField("Total", TypeFormula),
+	}
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		want   []*FieldViolation
+	}{
+		{
+			name: "valid",
+			fields: map[string]interface{}{
+				"Title":    "order",
+				"Progress": 0.5,
+				"Status":   "Open",
+				"Labels":   []string{"Open", "Closed"},
+				"Owner":    []map[string]string{{"id": "ou_1"}},
+				"Parent":   []string{"rec1", "rec2"},
+				"Due":      int64(1700000000000),
+				"Done":     true,
+				"Link":     map[string]string{"link": "https://example.com", "text": "example"},
+				"Files":    []map[string]string{{"file_token": "box1"}},
+				"Total":    nil,
+			},
+		},
+		{
+			name:   "field_not_found",
+			fields: map[string]interface{}{"Missing": "x"},
+			want:   []*FieldViolation{{FieldName: "Missing", Reason: "field not found", stale: true}},
+		},
+		{
+			name:   "unknown_option",
+			fields: map[string]interface{}{"Status": "Pending", "Labels": []string{"Open", "Later"}},
+			want: []*FieldViolation{
+				{FieldName: "Labels", Reason: `unknown option "Later"`, stale: true},
+				{FieldName: "Status", Reason: `unknown option "Pending"`, stale: true},
+			},
+		},
+		{
+			name:   "wrong_types",
+			fields: map[string]interface{}{"Title": 1, "Due": "tomorrow", "Done": "yes", "Link": "https://example.com"},
+			want: []*FieldViolation{
+				{FieldName: "Done", Reason: "must be a bool"},
+				{FieldName: "Due", Reason: "must be a unix timestamp in milliseconds"},
+				{FieldName: "Link", Reason: "must be an object with link and text"},
+				{FieldName: "Title", Reason: "must be a string"},
+			},
+		},
+		{
+			name:   "out_of_range",
+			fields: map[string]interface{}{"Progress": 2},
+			want:   []*FieldViolation{{FieldName: "Progress", Reason: "must be at most 1"}},
+		},
+		{
+			name:   "single_person",
+			fields: map[string]interface{}{"Owner": []map[string]string{{"id": "ou_1"}, {"id": "ou_2"}}},
+			want:   []*FieldViolation{{FieldName: "Owner", Reason: "only one person is allowed"}},
+		},
+		{
+			name:   "read_only",
+			fields: map[string]interface{}{"Total": 3},
+			want:   []*FieldViolation{{FieldName: "Total", Reason: "field is read only"}},
+		},
+		{
+			name:   "attachment_without_token",
+			fields: map[string]interface{}{"Files": []map[string]string{{"name": "a.txt"}}},
+			want:   []*FieldViolation{{FieldName: "Files", Reason: "must be a list of objects with file_token"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateRecords(fields, []*AppTableRecord{NewAppTableRecordBuilder().Fields(tt.fields).Build()})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateRecords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name           string
+		fields         map[string]interface{}
+		schemaAge      time.Duration
+		wantViolations int
+		wantFetches    int
+	}{
+		{name: "valid", fields: map[string]interface{}{"Title": "order"}, schemaAge: time.Minute, wantFetches: 1},
+		{name: "invalid_value_not_refreshed", fields: map[string]interface{}{"Amount": "x"}, schemaAge: time.Minute, wantViolations: 1, wantFetches: 1},
+		{name: "stale_refreshed", fields: map[string]interface{}{"Missing": "x"}, schemaAge: time.Minute, wantViolations: 1, wantFetches: 2},
+		{name: "stale_rate_limited", fields: map[string]interface{}{"Missing": "x"}, wantViolations: 1, wantFetches: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeBitableServer(t)
+			validator := server.service().NewRecordValidator()
+			ctx := context.Background()
+			if _, err := validator.schemas.get(ctx, "app", "tblOrders"); err != nil {
+				t.Fatal(err)
+			}
+			validator.schemas.store.entries["app/tblOrders"].schema.loadedAt = time.Now().Add(-tt.schemaAge)
+
+			violations, err := validator.Validate(ctx, "app", "tblOrders", []*AppTableRecord{NewAppTableRecordBuilder().Fields(tt.fields).Build()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(violations) != tt.wantViolations {
+				t.Errorf("got %d violations, want %d: %v", len(violations), tt.wantViolations, violations)
+			}
+			if fetches := len(server.find("/fields")); fetches != tt.wantFetches {
+				t.Errorf("fetched fields %d times, want %d", fetches, tt.wantFetches)
+			}
+		})
+	}
+}
+
+func TestRecordValidator_AppToken(t *testing.T) {
+	server := newFakeBitableServer(t)
+	service := server.service()
+	service.NewRecordValidator().Register()
+	ctx := context.Background()
+	record := NewAppTableRecordBuilder().Fields(map[string]interface{}{"Title": "order"}).Build()
+	for _, appToken := range []string{"other", "", "other"} {
+		builder := NewCreateAppTableRecordReqBuilder().TableId("tblOrders").AppTableRecord(record)
+		if appToken != "" {
+			builder.AppToken(appToken)
+		}
+		if _, err := service.AppTableRecord.Create(ctx, builder.Build()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got []string
+	for _, request := range server.find("/fields") {
+		got = append(got, request.path)
+	}
+	want := []string{
+		"/open-apis/bitable/v1/apps/other/tables/tblOrders/fields",
+		"/open-apis/bitable/v1/apps/app/tables/tblOrders/fields",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fetched fields %v, want %v", got, want)
+	}
+}
+
+func TestTableSchemaCache_ConcurrentGet(t *testing.T) {
+	server := newFakeBitableServer(t)
+	schemas := newTableSchemaCache(server.service(), time.Minute, nil)
+	var wg sync.WaitGroup
+	results := make([]*tableSchema, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			schema, err := schemas.get(context.Background(), "app", "tblOrders")
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = schema
+		}(i)
+	}
+	wg.Wait()
+	if fetches := len(server.find("/fields")); fetches != 1 {
+		t.Errorf("fetched fields %d times, want 1", fetches)
+	}
+	for _, schema := range results[1:] {
+		if schema != results[0] {
+			t.Fatal("concurrent gets returned different schemas")
+		}
+	}
+}
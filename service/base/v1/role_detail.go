@@ -0,0 +1,100 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// AppRoleDetail 带字段权限的自定义角色
+//
+// - 生成的 AppRoleTableRoleFieldPerm 没有声明字段，无法携带字段权限；需要读写字段权限时使用本类型
+//
+// - 序列化时 TableRoles 覆盖 AppRole 中的同名字段
+type AppRoleDetail struct {
+	*AppRole
+	TableRoles []*AppRoleTableRoleDetail `json:"table_roles,omitempty"` // 数据表权限
+}
+
+// AppRoleTableRoleDetail 带字段权限的数据表权限
+type AppRoleTableRoleDetail struct {
+	*AppRoleTableRole
+	FieldPerm map[string]int `json:"field_perm,omitempty"` // 字段名 -> 字段权限，取值见 FieldPerm* 常量
+}
+
+type listAppRoleDetailResp struct {
+	larkcore.CodeError
+	Data *struct {
+		Items     []*AppRoleDetail `json:"items,omitempty"`
+		PageToken *string          `json:"page_token,omitempty"`
+		HasMore   *bool            `json:"has_more,omitempty"`
+	} `json:"data"`
+}
+
+// 获取多维表格的全部自定义角色，保留字段权限
+func (a *appRole) ListAll(ctx context.Context, options ...larkcore.RequestOptionFunc) ([]*AppRoleDetail, error) {
+	var roles []*AppRoleDetail
+	pageToken := ""
+	for {
+		builder := NewListAppRoleReqBuilder()
+		if pageToken != "" {
+			builder.PageToken(pageToken)
+		}
+		resp, err := a.List(ctx, builder.Build(), options...)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success() {
+			return nil, resp.CodeError
+		}
+		detail := &listAppRoleDetailResp{}
+		if err := json.Unmarshal(resp.RawBody, detail); err != nil {
+			return nil, err
+		}
+		if detail.Data == nil {
+			return roles, nil
+		}
+		for _, role := range detail.Data.Items {
+			if role.AppRole == nil {
+				role.AppRole = &AppRole{}
+			}
+			for _, tableRole := range role.TableRoles {
+				if tableRole.AppRoleTableRole == nil {
+					tableRole.AppRoleTableRole = &AppRoleTableRole{}
+				}
+			}
+			roles = append(roles, role)
+		}
+		if detail.Data.HasMore == nil || !*detail.Data.HasMore || detail.Data.PageToken == nil || *detail.Data.PageToken == "" {
+			return roles, nil
+		}
+		pageToken = *detail.Data.PageToken
+	}
+}
+
+// 新增带字段权限的自定义角色
+func (a *appRole) CreateDetail(ctx context.Context, role *AppRoleDetail, options ...larkcore.RequestOptionFunc) (*CreateAppRoleResp, error) {
+	req := NewCreateAppRoleReqBuilder().Build()
+	req.apiReq.Body = role
+	return a.Create(ctx, req, options...)
+}
+
+// 全量更新带字段权限的自定义角色
+func (a *appRole) UpdateDetail(ctx context.Context, roleId string, role *AppRoleDetail, options ...larkcore.RequestOptionFunc) (*UpdateAppRoleResp, error) {
+	req := NewUpdateAppRoleReqBuilder().RoleId(roleId).Build()
+	req.apiReq.Body = role
+	return a.Update(ctx, req, options...)
+}
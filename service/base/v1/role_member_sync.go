@@ -0,0 +1,324 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 批量新增、删除协作者接口单次支持的协作者数上限
+const roleMemberBatchLimit = 100
+
+// 获取自定义角色的全部协作者
+func (a *appRoleMember) ListAll(ctx context.Context, roleId string, options ...larkcore.RequestOptionFunc) ([]*AppRoleMember, error) {
+	iterator, err := a.ListByIterator(ctx, NewListAppRoleMemberReqBuilder().
+		RoleId(roleId).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	var members []*AppRoleMember
+	for {
+		hasMore, member, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return members, nil
+		}
+		members = append(members, member)
+	}
+}
+
+// 按单次上限分批新增协作者
+func (a *appRoleMember) BatchCreateAll(ctx context.Context, roleId string, members []*AppRoleMemberId, options ...larkcore.RequestOptionFunc) error {
+	for start := 0; start < len(members); start += roleMemberBatchLimit {
+		end := start + roleMemberBatchLimit
+		if end > len(members) {
+			end = len(members)
+		}
+		resp, err := a.BatchCreate(ctx, NewBatchCreateAppRoleMemberReqBuilder().
+			RoleId(roleId).
+			Body(NewBatchCreateAppRoleMemberReqBodyBuilder().
+				MemberList(members[start:end]).
+				Build()).
+			Build(), options...)
+		if err != nil {
+			return err
+		}
+		if !resp.Success() {
+			return resp.CodeError
+		}
+	}
+	return nil
+}
+
+// 按单次上限分批删除协作者
+func (a *appRoleMember) BatchDeleteAll(ctx context.Context, roleId string, members []*AppRoleMemberId, options ...larkcore.RequestOptionFunc) error {
+	for start := 0; start < len(members); start += roleMemberBatchLimit {
+		end := start + roleMemberBatchLimit
+		if end > len(members) {
+			end = len(members)
+		}
+		resp, err := a.BatchDelete(ctx, NewBatchDeleteAppRoleMemberReqBuilder().
+			RoleId(roleId).
+			Body(NewBatchDeleteAppRoleMemberReqBodyBuilder().
+				MemberList(members[start:end]).
+				Build()).
+			Build(), options...)
+		if err != nil {
+			return err
+		}
+		if !resp.Success() {
+			return resp.CodeError
+		}
+	}
+	return nil
+}
+
+// 对比现有协作者与期望的协作者，返回需要新增和删除的协作者
+//
+// - 现有协作者携带的任意一个 ID（包括 member_id）与期望的 ID 相同即视为匹配，不要求 ID 类型一致，
+// 这样名单使用 union_id 而列表只返回 open_id、member_id 时，仍能按 member_id 匹配；不同类型的 ID 前缀不同，不会误配
+//
+// - 需要删除的协作者按 open_id、union_id、user_id、chat_id、open_department_id、department_id 的顺序选取 ID
+func diffRoleMembers(current []*AppRoleMember, desired []*AppRoleMemberId) ([]*AppRoleMemberId, []*AppRoleMemberId) {
+	matched := make([]bool, len(current))
+	var adds []*AppRoleMemberId
+	seen := map[string]bool{}
+	for _, member := range desired {
+		if member.Type == nil || member.Id == nil {
+			continue
+		}
+		key := *member.Type + ":" + *member.Id
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		found := false
+		for i, existing := range current {
+			if roleMemberHasId(existing, *member.Id) {
+				matched[i] = true
+				found = true
+			}
+		}
+		if !found {
+			adds = append(adds, member)
+		}
+	}
+
+	var removes []*AppRoleMemberId
+	for i, existing := range current {
+		if matched[i] {
+			continue
+		}
+		for _, idType := range []string{MemberIdTypeOpenID, MemberIdTypeUnionID, MemberIdTypeUserID, MemberIdTypeChatID, MemberIdTypeOpenDepartmentID, MemberIdTypeDepartmentID} {
+			if id := roleMemberId(existing, idType); id != "" {
+				removes = append(removes, NewAppRoleMemberIdBuilder().Type(idType).Id(id).Build())
+				break
+			}
+		}
+	}
+	return adds, removes
+}
+
+// 协作者携带的任意一个 ID 是否为 id
+func roleMemberHasId(member *AppRoleMember, id string) bool {
+	if id == "" {
+		return false
+	}
+	if stringValue(member.MemberId) == id {
+		return true
+	}
+	for _, idType := range []string{MemberIdTypeOpenID, MemberIdTypeUnionID, MemberIdTypeUserID, MemberIdTypeChatID, MemberIdTypeOpenDepartmentID, MemberIdTypeDepartmentID} {
+		if roleMemberId(member, idType) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// 协作者在指定 ID 类型下的 ID，没有时返回空字符串
+func roleMemberId(member *AppRoleMember, idType string) string {
+	var id *string
+	switch idType {
+	case MemberIdTypeOpenID:
+		id = member.OpenId
+	case MemberIdTypeUnionID:
+		id = member.UnionId
+	case MemberIdTypeUserID:
+		id = member.UserId
+	case MemberIdTypeChatID:
+		id = member.ChatId
+	case MemberIdTypeDepartmentID:
+		id = member.DepartmentId
+	case MemberIdTypeOpenDepartmentID:
+		id = member.OpenDepartmentId
+	}
+	if id == nil {
+		return ""
+	}
+	return *id
+}
+
+// ErrTooManyRemovals 本次同步需要删除的协作者超过了上限，未执行任何变更
+var ErrTooManyRemovals = errors.New("too many role member removals")
+
+// RoleMemberSyncResult 单个角色的协作者同步结果
+type RoleMemberSyncResult struct {
+	RoleId  string             // 角色ID
+	Adds    []*AppRoleMemberId // 需要新增的协作者
+	Removes []*AppRoleMemberId // 需要删除的协作者
+	Applied bool               // 是否已执行
+	Err     error              // 获取或变更协作者时遇到的错误
+}
+
+// RosterSyncReport 协作者同步报告
+type RosterSyncReport struct {
+	DryRun  bool
+	Results []*RoleMemberSyncResult
+}
+
+func (r *RosterSyncReport) String() string {
+	var lines []string
+	for _, result := range r.Results {
+		for _, member := range result.Adds {
+			lines = append(lines, fmt.Sprintf("add %s %s:%s", result.RoleId, *member.Type, *member.Id))
+		}
+		for _, member := range result.Removes {
+			lines = append(lines, fmt.Sprintf("remove %s %s:%s", result.RoleId, *member.Type, *member.Id))
+		}
+		if result.Err != nil {
+			lines = append(lines, fmt.Sprintf("error %s %v", result.RoleId, result.Err))
+		}
+	}
+	if len(lines) == 0 {
+		return "no changes"
+	}
+	if r.DryRun {
+		lines = append([]string{"dry run, nothing applied"}, lines...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RosterSyncer 按外部名单同步自定义角色的协作者
+//
+// - 名单中未出现的角色不做处理；名单中角色的协作者列表为空表示清空该角色
+//
+// - 删除数超过 maxRemovals 时返回 ErrTooManyRemovals 且不执行任何变更，maxRemovals 小于 0 表示不限制
+type RosterSyncer struct {
+	service     *BaseService
+	maxRemovals int
+	dryRun      bool
+	options     []larkcore.RequestOptionFunc
+}
+
+// 创建协作者同步器，dryRun 为 true 时只生成报告不执行变更
+func (b *BaseService) NewRosterSyncer(maxRemovals int, dryRun bool, options ...larkcore.RequestOptionFunc) *RosterSyncer {
+	return &RosterSyncer{service: b, maxRemovals: maxRemovals, dryRun: dryRun, options: options}
+}
+
+// 同步协作者，desired 的 key 为角色ID
+//
+// - 单个角色变更失败时记录在对应结果的 Err 中，不影响其它角色
+func (s *RosterSyncer) Sync(ctx context.Context, desired map[string][]*AppRoleMemberId) (*RosterSyncReport, error) {
+	roleIds := make([]string, 0, len(desired))
+	for roleId, members := range desired {
+		for _, member := range members {
+			if err := validateRosterMember(member); err != nil {
+				return nil, fmt.Errorf("role %s: %v", roleId, err)
+			}
+		}
+		roleIds = append(roleIds, roleId)
+	}
+	sort.Strings(roleIds)
+
+	report := &RosterSyncReport{DryRun: s.dryRun}
+	removals := 0
+	for _, roleId := range roleIds {
+		result := &RoleMemberSyncResult{RoleId: roleId}
+		report.Results = append(report.Results, result)
+		current, err := s.service.AppRoleMember.ListAll(ctx, roleId, s.options...)
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		result.Adds, result.Removes = diffRoleMembers(current, desired[roleId])
+		removals += len(result.Removes)
+	}
+	if s.maxRemovals >= 0 && removals > s.maxRemovals {
+		return report, fmt.Errorf("%w: %d removals exceed the limit of %d", ErrTooManyRemovals, removals, s.maxRemovals)
+	}
+	if s.dryRun {
+		return report, nil
+	}
+
+	for _, result := range report.Results {
+		if result.Err != nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if result.Err = s.service.AppRoleMember.BatchDeleteAll(ctx, result.RoleId, result.Removes, s.options...); result.Err != nil {
+			continue
+		}
+		if result.Err = s.service.AppRoleMember.BatchCreateAll(ctx, result.RoleId, result.Adds, s.options...); result.Err != nil {
+			continue
+		}
+		result.Applied = true
+	}
+	return report, nil
+}
+
+// 读取 CSV 名单，每行依次为角色ID、协作者 ID 类型、协作者 ID，首行为表头
+func ParseRoster(r io.Reader) (map[string][]*AppRoleMemberId, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	roster := map[string][]*AppRoleMemberId{}
+	for i, row := range rows {
+		if i == 0 {
+			continue
+		}
+		member := NewAppRoleMemberIdBuilder().Type(row[1]).Id(row[2]).Build()
+		if err := validateRosterMember(member); err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+		roster[row[0]] = append(roster[row[0]], member)
+	}
+	return roster, nil
+}
+
+func validateRosterMember(member *AppRoleMemberId) error {
+	if member == nil || member.Type == nil || member.Id == nil || *member.Id == "" {
+		return errors.New("member id and type are required")
+	}
+	switch *member.Type {
+	case MemberIdTypeOpenID, MemberIdTypeUnionID, MemberIdTypeUserID, MemberIdTypeChatID, MemberIdTypeDepartmentID, MemberIdTypeOpenDepartmentID:
+		return nil
+	}
+	return fmt.Errorf("invalid member id type %q", *member.Type)
+}
@@ -0,0 +1,147 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestMemberId(idType string, id string) *AppRoleMemberId {
+	return NewAppRoleMemberIdBuilder().Type(idType).Id(id).Build()
+}
+
+func TestDiffRoleMembers(t *testing.T) {
+	alice := NewAppRoleMemberBuilder().MemberId("ou_alice").OpenId("ou_alice").UnionId("on_alice").Build()
+	bob := NewAppRoleMemberBuilder().MemberId("ou_bob").OpenId("ou_bob").Build()
+	group := NewAppRoleMemberBuilder().MemberId("oc_group").ChatId("oc_group").Build()
+	tests := []struct {
+		name        string
+		current     []*AppRoleMember
+		desired     []*AppRoleMemberId
+		wantAdds    []*AppRoleMemberId
+		wantRemoves []*AppRoleMemberId
+	}{
+		{
+			name:    "unchanged",
+			current: []*AppRoleMember{alice, group},
+			desired: []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_alice"), newTestMemberId(MemberIdTypeChatID, "oc_group")},
+		},
+		{
+			name:        "add_and_remove",
+			current:     []*AppRoleMember{alice, bob},
+			desired:     []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_alice"), newTestMemberId(MemberIdTypeChatID, "oc_new")},
+			wantAdds:    []*AppRoleMemberId{newTestMemberId(MemberIdTypeChatID, "oc_new")},
+			wantRemoves: []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_bob")},
+		},
+		{
+			name:    "other_id_type",
+			current: []*AppRoleMember{alice},
+			desired: []*AppRoleMemberId{newTestMemberId(MemberIdTypeUnionID, "on_alice")},
+		},
+		{
+			// 列表未返回 union_id，但 member_id 与名单中的 ID 相同
+			name:    "member_id_only",
+			current: []*AppRoleMember{NewAppRoleMemberBuilder().MemberId("on_carol").OpenId("ou_carol").Build()},
+			desired: []*AppRoleMemberId{newTestMemberId(MemberIdTypeUnionID, "on_carol")},
+		},
+		{
+			name:     "duplicates_added_once",
+			desired:  []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_dave"), newTestMemberId(MemberIdTypeOpenID, "ou_dave")},
+			wantAdds: []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_dave")},
+		},
+		{
+			name:        "clear",
+			current:     []*AppRoleMember{alice, group},
+			desired:     []*AppRoleMemberId{},
+			wantRemoves: []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_alice"), newTestMemberId(MemberIdTypeChatID, "oc_group")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adds, removes := diffRoleMembers(tt.current, tt.desired)
+			if !reflect.DeepEqual(adds, tt.wantAdds) {
+				t.Errorf("adds = %v, want %v", adds, tt.wantAdds)
+			}
+			if !reflect.DeepEqual(removes, tt.wantRemoves) {
+				t.Errorf("removes = %v, want %v", removes, tt.wantRemoves)
+			}
+		})
+	}
+}
+
+func TestRosterSyncer_Sync(t *testing.T) {
+	roleMembers := map[string][]map[string]interface{}{
+		"rol1": {
+			{"member_id": "ou_alice", "open_id": "ou_alice"},
+			{"member_id": "ou_bob", "open_id": "ou_bob"},
+		},
+		"rol2": {
+			{"member_id": "ou_carol", "open_id": "ou_carol"},
+		},
+	}
+	desired := map[string][]*AppRoleMemberId{
+		"rol1": {newTestMemberId(MemberIdTypeOpenID, "ou_alice"), newTestMemberId(MemberIdTypeOpenID, "ou_dave")},
+		"rol2": {},
+	}
+	tests := []struct {
+		name        string
+		maxRemovals int
+		dryRun      bool
+		wantErr     error
+		wantApplied bool
+		wantWrites  int
+	}{
+		{name: "applied", maxRemovals: 2, wantApplied: true, wantWrites: 3},
+		{name: "unlimited", maxRemovals: -1, wantApplied: true, wantWrites: 3},
+		{name: "dry_run", maxRemovals: 2, dryRun: true},
+		{name: "too_many_removals", maxRemovals: 1, wantErr: ErrTooManyRemovals},
+		{name: "too_many_removals_dry_run", maxRemovals: 1, dryRun: true, wantErr: ErrTooManyRemovals},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeBitableServer(t)
+			server.roleMembers = roleMembers
+			report, err := server.service().NewRosterSyncer(tt.maxRemovals, tt.dryRun).Sync(context.Background(), desired)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Sync() error = %v, want %v", err, tt.wantErr)
+			}
+			if report == nil || len(report.Results) != 2 {
+				t.Fatalf("Sync() report = %v", report)
+			}
+			if report.DryRun != tt.dryRun {
+				t.Errorf("report.DryRun = %t, want %t", report.DryRun, tt.dryRun)
+			}
+			for _, result := range report.Results {
+				if result.Applied != tt.wantApplied {
+					t.Errorf("role %s applied = %t, want %t", result.RoleId, result.Applied, tt.wantApplied)
+				}
+			}
+			wantRol1 := &RoleMemberSyncResult{
+				RoleId:  "rol1",
+				Adds:    []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_dave")},
+				Removes: []*AppRoleMemberId{newTestMemberId(MemberIdTypeOpenID, "ou_bob")},
+				Applied: tt.wantApplied,
+			}
+			if !reflect.DeepEqual(report.Results[0], wantRol1) {
+				t.Errorf("rol1 result = %+v, want %+v", report.Results[0], wantRol1)
+			}
+			writes := len(server.find("/batch_create")) + len(server.find("/batch_delete"))
+			if writes != tt.wantWrites {
+				t.Errorf("got %d batch writes, want %d", writes, tt.wantWrites)
+			}
+		})
+	}
+}
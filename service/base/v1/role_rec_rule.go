@@ -0,0 +1,193 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	RecRuleOperatorIs             = "is"             // 等于
+	RecRuleOperatorIsNot          = "isNot"          // 不等于
+	RecRuleOperatorContains       = "contains"       // 包含
+	RecRuleOperatorDoesNotContain = "doesNotContain" // 不包含
+	RecRuleOperatorIsEmpty        = "isEmpty"        // 为空
+	RecRuleOperatorIsNotEmpty     = "isNotEmpty"     // 不为空
+)
+
+const (
+	RecRuleConjunctionAnd = "and" // 满足全部条件
+	RecRuleConjunctionOr  = "or"  // 满足任一条件
+)
+
+const (
+	RecRuleOtherPermNone = 0 // 其他记录不可查看
+	RecRuleOtherPermRead = 1 // 其他记录仅可阅读
+)
+
+// 记录筛选条件中各字段类型支持的运算符
+var recRuleOperators = map[int][]string{
+	TypeSingleSelect: {RecRuleOperatorIs, RecRuleOperatorIsNot, RecRuleOperatorIsEmpty, RecRuleOperatorIsNotEmpty},
+	TypeMultiSelect:  {RecRuleOperatorIs, RecRuleOperatorContains, RecRuleOperatorDoesNotContain, RecRuleOperatorIsEmpty, RecRuleOperatorIsNotEmpty},
+}
+
+// RecRuleBuilder 按字段名和选项名构造角色的记录筛选条件
+//
+// - 通过数据表的字段定义将选项名解析为选项 ID，并填入字段类型
+//
+// - 字段不存在、字段类型不支持、运算符与字段类型不匹配或选项不存在时，Build 返回错误
+type RecRuleBuilder struct {
+	fields      map[string]*AppTableField
+	conditions  []*AppRoleTableRoleRecRuleCondition
+	conjunction string
+	otherPerm   *int
+	errs        []error
+}
+
+// 获取数据表字段并创建记录筛选条件构造器
+func (b *BaseService) NewRecRuleBuilder(ctx context.Context, tableId string, options ...larkcore.RequestOptionFunc) (*RecRuleBuilder, error) {
+	fields, err := b.AppTableField.ListAll(ctx, tableId, options...)
+	if err != nil {
+		return nil, err
+	}
+	return NewRecRuleBuilder(fields), nil
+}
+
+// 使用已获取的字段定义创建记录筛选条件构造器
+func NewRecRuleBuilder(fields []*AppTableField) *RecRuleBuilder {
+	builder := &RecRuleBuilder{fields: map[string]*AppTableField{}, conjunction: RecRuleConjunctionAnd}
+	for _, field := range fields {
+		if field.FieldName != nil {
+			builder.fields[*field.FieldName] = field
+		}
+	}
+	return builder
+}
+
+// 添加一个条件，optionNames 为单选、多选字段的选项名；为空、不为空条件不需要选项
+func (r *RecRuleBuilder) Where(fieldName string, operator string, optionNames ...string) *RecRuleBuilder {
+	condition, err := r.condition(fieldName, operator, optionNames)
+	if err != nil {
+		r.errs = append(r.errs, err)
+		return r
+	}
+	r.conditions = append(r.conditions, condition)
+	return r
+}
+
+// 多个条件之间的关系，取值见 RecRuleConjunction* 常量，默认为 and
+func (r *RecRuleBuilder) Conjunction(conjunction string) *RecRuleBuilder {
+	if conjunction != RecRuleConjunctionAnd && conjunction != RecRuleConjunctionOr {
+		r.errs = append(r.errs, fmt.Errorf("invalid conjunction %q, must be %q or %q", conjunction, RecRuleConjunctionAnd, RecRuleConjunctionOr))
+		return r
+	}
+	r.conjunction = conjunction
+	return r
+}
+
+// 不满足条件的其他记录的权限，取值见 RecRuleOtherPerm* 常量，仅在数据表权限为可编辑时有意义
+func (r *RecRuleBuilder) OtherPerm(otherPerm int) *RecRuleBuilder {
+	if otherPerm != RecRuleOtherPermNone && otherPerm != RecRuleOtherPermRead {
+		r.errs = append(r.errs, fmt.Errorf("invalid other_perm %d", otherPerm))
+		return r
+	}
+	r.otherPerm = &otherPerm
+	return r
+}
+
+// 生成记录筛选条件，所有校验错误会合并返回
+func (r *RecRuleBuilder) Build() (*AppRoleTableRoleRecRule, error) {
+	if len(r.errs) > 0 {
+		messages := make([]string, 0, len(r.errs))
+		for _, err := range r.errs {
+			messages = append(messages, err.Error())
+		}
+		return nil, errors.New(strings.Join(messages, "; "))
+	}
+	if len(r.conditions) == 0 {
+		return nil, errors.New("record rule has no conditions")
+	}
+	builder := NewAppRoleTableRoleRecRuleBuilder().
+		Conditions(r.conditions).
+		Conjunction(r.conjunction)
+	if r.otherPerm != nil {
+		builder.OtherPerm(*r.otherPerm)
+	}
+	return builder.Build(), nil
+}
+
+func (r *RecRuleBuilder) condition(fieldName string, operator string, optionNames []string) (*AppRoleTableRoleRecRuleCondition, error) {
+	field, ok := r.fields[fieldName]
+	if !ok || field.Type == nil {
+		return nil, fmt.Errorf("field %s not found", fieldName)
+	}
+	operators, ok := recRuleOperators[*field.Type]
+	if !ok {
+		return nil, fmt.Errorf("field %s of type %d cannot be used in record rules, only single and multiple select fields are supported", fieldName, *field.Type)
+	}
+	valid := false
+	for _, candidate := range operators {
+		valid = valid || candidate == operator
+	}
+	if !valid {
+		return nil, fmt.Errorf("operator %q is not valid for field %s, expected one of %s", operator, fieldName, strings.Join(operators, ", "))
+	}
+
+	switch operator {
+	case RecRuleOperatorIsEmpty, RecRuleOperatorIsNotEmpty:
+		if len(optionNames) > 0 {
+			return nil, fmt.Errorf("operator %q on field %s takes no options", operator, fieldName)
+		}
+	case RecRuleOperatorIs, RecRuleOperatorIsNot:
+		if *field.Type == TypeSingleSelect && len(optionNames) != 1 {
+			return nil, fmt.Errorf("operator %q on single select field %s takes exactly one option", operator, fieldName)
+		}
+		fallthrough
+	default:
+		if len(optionNames) == 0 {
+			return nil, fmt.Errorf("operator %q on field %s requires options", operator, fieldName)
+		}
+	}
+
+	optionIds := make([]string, 0, len(optionNames))
+	for _, name := range optionNames {
+		id, err := fieldOptionId(field, name)
+		if err != nil {
+			return nil, err
+		}
+		optionIds = append(optionIds, id)
+	}
+	return NewAppRoleTableRoleRecRuleConditionBuilder().
+		FieldName(fieldName).
+		Operator(operator).
+		Value(optionIds).
+		FieldType(*field.Type).
+		Build(), nil
+}
+
+// 按选项名查找单选、多选字段的选项 ID
+func fieldOptionId(field *AppTableField, name string) (string, error) {
+	if field.Property != nil {
+		for _, option := range field.Property.Options {
+			if option.Name != nil && *option.Name == name && option.Id != nil {
+				return *option.Id, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("option %q not found in field %s", name, stringValue(field.FieldName))
+}
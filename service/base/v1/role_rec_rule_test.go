@@ -0,0 +1,148 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestSelectField(name string, fieldType int, options map[string]string) *AppTableField {
+	var propertyOptions []*AppTableFieldPropertyOption
+	for id, optionName := range options {
+		propertyOptions = append(propertyOptions, NewAppTableFieldPropertyOptionBuilder().Id(id).Name(optionName).Build())
+	}
+	return NewAppTableFieldBuilder().
+		FieldName(name).
+		Type(fieldType).
+		Property(NewAppTableFieldPropertyBuilder().Options(propertyOptions).Build()).
+		Build()
+}
+
+func TestRecRuleBuilder(t *testing.T) {
+	fields := []*AppTableField{
+		newTestSelectField("Region", TypeSingleSelect, map[string]string{"opt_n": "North", "opt_s": "South"}),
+		newTestSelectField("Tags", TypeMultiSelect, map[string]string{"opt_v": "VIP", "opt_b": "Blocked"}),
+		newTestField("Owner", TypeUser),
+		newTestField("Title", TypeText),
+	}
+	tests := []struct {
+		name    string
+		build   func(*RecRuleBuilder) *RecRuleBuilder
+		want    *AppRoleTableRoleRecRule
+		wantErr string
+	}{
+		{
+			name: "single_select_is",
+			build: func(r *RecRuleBuilder) *RecRuleBuilder {
+				return r.Where("Region", RecRuleOperatorIs, "South")
+			},
+			want: NewAppRoleTableRoleRecRuleBuilder().
+				Conditions([]*AppRoleTableRoleRecRuleCondition{
+					NewAppRoleTableRoleRecRuleConditionBuilder().FieldName("Region").Operator(RecRuleOperatorIs).Value([]string{"opt_s"}).FieldType(TypeSingleSelect).Build(),
+				}).
+				Conjunction(RecRuleConjunctionAnd).
+				Build(),
+		},
+		{
+			name: "multi_select_or_with_other_perm",
+			build: func(r *RecRuleBuilder) *RecRuleBuilder {
+				return r.Where("Tags", RecRuleOperatorContains, "VIP", "Blocked").
+					Where("Region", RecRuleOperatorIsEmpty).
+					Conjunction(RecRuleConjunctionOr).
+					OtherPerm(RecRuleOtherPermRead)
+			},
+			want: NewAppRoleTableRoleRecRuleBuilder().
+				Conditions([]*AppRoleTableRoleRecRuleCondition{
+					NewAppRoleTableRoleRecRuleConditionBuilder().FieldName("Tags").Operator(RecRuleOperatorContains).Value([]string{"opt_v", "opt_b"}).FieldType(TypeMultiSelect).Build(),
+					NewAppRoleTableRoleRecRuleConditionBuilder().FieldName("Region").Operator(RecRuleOperatorIsEmpty).Value([]string{}).FieldType(TypeSingleSelect).Build(),
+				}).
+				Conjunction(RecRuleConjunctionOr).
+				OtherPerm(RecRuleOtherPermRead).
+				Build(),
+		},
+		{
+			name:    "no_conditions",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r },
+			wantErr: "record rule has no conditions",
+		},
+		{
+			name:    "field_not_found",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Status", RecRuleOperatorIs, "Open") },
+			wantErr: "field Status not found",
+		},
+		{
+			name:    "user_field_rejected",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Owner", RecRuleOperatorIs, "ou_1") },
+			wantErr: "field Owner of type 11 cannot be used in record rules",
+		},
+		{
+			name:    "text_field_rejected",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Title", RecRuleOperatorContains, "x") },
+			wantErr: "field Title of type 1 cannot be used in record rules",
+		},
+		{
+			name:    "operator_not_valid_for_type",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Region", RecRuleOperatorContains, "North") },
+			wantErr: `operator "contains" is not valid for field Region`,
+		},
+		{
+			name:    "single_select_multiple_options",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Region", RecRuleOperatorIs, "North", "South") },
+			wantErr: "takes exactly one option",
+		},
+		{
+			name:    "empty_operator_with_options",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Tags", RecRuleOperatorIsNotEmpty, "VIP") },
+			wantErr: "takes no options",
+		},
+		{
+			name:    "missing_options",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Tags", RecRuleOperatorContains) },
+			wantErr: "requires options",
+		},
+		{
+			name:    "unknown_option",
+			build:   func(r *RecRuleBuilder) *RecRuleBuilder { return r.Where("Tags", RecRuleOperatorIs, "Gold") },
+			wantErr: `option "Gold" not found in field Tags`,
+		},
+		{
+			name: "errors_joined",
+			build: func(r *RecRuleBuilder) *RecRuleBuilder {
+				return r.Where("Status", RecRuleOperatorIs, "Open").Conjunction("xor").OtherPerm(2)
+			},
+			wantErr: `field Status not found; invalid conjunction "xor", must be "and" or "or"; invalid other_perm 2`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build(NewRecRuleBuilder(fields)).Build()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Build() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				gotJSON, _ := json.Marshal(got)
+				wantJSON, _ := json.Marshal(tt.want)
+				t.Errorf("Build() = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
@@ -0,0 +1,584 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	TablePermNone   = 0 // 无权限
+	TablePermRead   = 1 // 可阅读
+	TablePermEdit   = 2 // 可编辑
+	TablePermManage = 4 // 可管理
+)
+
+const (
+	FieldPermRead = 1 // 可阅读
+	FieldPermEdit = 2 // 可编辑
+)
+
+const (
+	BlockPermNone = 0 // 无权限
+	BlockPermRead = 1 // 可阅读
+)
+
+const (
+	BlockTypeDashboard = "dashboard" // 仪表盘
+)
+
+const (
+	RoleChangeCreate       = "create"        // 新建角色
+	RoleChangeUpdate       = "update"        // 更新角色
+	RoleChangeDelete       = "delete"        // 删除角色
+	RoleChangeAddMember    = "add_member"    // 新增协作者
+	RoleChangeRemoveMember = "remove_member" // 删除协作者
+)
+
+// RoleSpec 以代码声明的自定义角色，可直接在 Go 中构造，也可由 JSON 解析得到
+type RoleSpec struct {
+	Name    string             `json:"name"`              // 角色名，用于与已有角色匹配
+	Tables  []*TableRoleSpec   `json:"tables"`            // 数据表权限，未列出的数据表无权限
+	Blocks  []*BlockRoleSpec   `json:"blocks,omitempty"`  // 仪表盘等 block 的权限
+	Members []*AppRoleMemberId `json:"members,omitempty"` // 协作者，为 nil 时不管理该角色的协作者
+}
+
+// TableRoleSpec 数据表权限
+type TableRoleSpec struct {
+	Table             string                   `json:"table"`                         // 数据表名
+	Perm              int                      `json:"perm"`                          // 数据表权限，取值见 TablePerm* 常量
+	FieldPerms        map[string]int           `json:"field_perms,omitempty"`         // 字段名 -> 字段权限，取值见 FieldPerm* 常量，仅在可编辑时有效；未列出的字段沿用数据表权限
+	RecRule           *AppRoleTableRoleRecRule `json:"rec_rule,omitempty"`            // 记录筛选条件
+	AllowAddRecord    bool                     `json:"allow_add_record,omitempty"`    // 是否可新增记录，仅在可编辑时有效
+	AllowDeleteRecord bool                     `json:"allow_delete_record,omitempty"` // 是否可删除记录，仅在可编辑时有效
+}
+
+// BlockRoleSpec block 权限
+type BlockRoleSpec struct {
+	BlockId   string `json:"block_id"`             // block ID，例如仪表盘 ID
+	BlockType string `json:"block_type,omitempty"` // block 类型，为空时按仪表盘处理
+	Perm      int    `json:"perm"`                 // block 权限，取值见 BlockPerm* 常量
+}
+
+// 从 JSON 解析角色声明
+//
+// - SDK 不引入第三方依赖，因此只支持 JSON；YAML 文件可先由调用方转换为 JSON，或使用 RoleSpecBuilder 在 Go 中构造
+func ParseRoleSpecs(data []byte) ([]*RoleSpec, error) {
+	var specs []*RoleSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, spec := range specs {
+		if err := spec.validate(); err != nil {
+			return nil, err
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("duplicate role spec %s", spec.Name)
+		}
+		names[spec.Name] = true
+	}
+	return specs, nil
+}
+
+func (s *RoleSpec) validate() error {
+	if s.Name == "" {
+		return errors.New("role spec without name")
+	}
+	tables := map[string]bool{}
+	for _, table := range s.Tables {
+		if table == nil {
+			return fmt.Errorf("role %s: nil table spec", s.Name)
+		}
+		if err := table.validate(); err != nil {
+			return fmt.Errorf("role %s: %w", s.Name, err)
+		}
+		if tables[table.Table] {
+			return fmt.Errorf("role %s: duplicate table %s", s.Name, table.Table)
+		}
+		tables[table.Table] = true
+	}
+	for _, block := range s.Blocks {
+		if block == nil || block.BlockId == "" {
+			return fmt.Errorf("role %s: block spec without block_id", s.Name)
+		}
+		if block.BlockType != "" && block.BlockType != BlockTypeDashboard {
+			return fmt.Errorf("role %s: block %s: invalid block_type %s", s.Name, block.BlockId, block.BlockType)
+		}
+		if block.Perm != BlockPermNone && block.Perm != BlockPermRead {
+			return fmt.Errorf("role %s: block %s: invalid perm %d", s.Name, block.BlockId, block.Perm)
+		}
+	}
+	return nil
+}
+
+func (s *TableRoleSpec) validate() error {
+	if s.Table == "" {
+		return errors.New("table spec without table")
+	}
+	switch s.Perm {
+	case TablePermNone, TablePermRead, TablePermEdit, TablePermManage:
+	default:
+		return fmt.Errorf("table %s: invalid perm %d", s.Table, s.Perm)
+	}
+	if s.Perm != TablePermEdit {
+		if len(s.FieldPerms) > 0 {
+			return fmt.Errorf("table %s: field_perms require perm %d", s.Table, TablePermEdit)
+		}
+		if s.AllowAddRecord || s.AllowDeleteRecord {
+			return fmt.Errorf("table %s: allow_add_record and allow_delete_record require perm %d", s.Table, TablePermEdit)
+		}
+	}
+	for field, perm := range s.FieldPerms {
+		if perm != FieldPermRead && perm != FieldPermEdit {
+			return fmt.Errorf("table %s: field %s: invalid perm %d", s.Table, field, perm)
+		}
+	}
+	return nil
+}
+
+// RoleSpecBuilder 在 Go 中构造角色声明，Build 时做与 ParseRoleSpecs 相同的校验
+type RoleSpecBuilder struct {
+	spec *RoleSpec
+}
+
+func NewRoleSpecBuilder(name string) *RoleSpecBuilder {
+	return &RoleSpecBuilder{spec: &RoleSpec{Name: name, Tables: []*TableRoleSpec{}}}
+}
+
+// 添加数据表权限，通常由 TableRoleSpecBuilder 生成
+func (r *RoleSpecBuilder) Table(table *TableRoleSpec) *RoleSpecBuilder {
+	r.spec.Tables = append(r.spec.Tables, table)
+	return r
+}
+
+// 添加仪表盘权限，取值见 BlockPerm* 常量
+func (r *RoleSpecBuilder) Dashboard(blockId string, perm int) *RoleSpecBuilder {
+	r.spec.Blocks = append(r.spec.Blocks, &BlockRoleSpec{BlockId: blockId, BlockType: BlockTypeDashboard, Perm: perm})
+	return r
+}
+
+// 声明角色的协作者；调用后即由声明管理该角色的协作者，不传参数表示移除全部协作者
+func (r *RoleSpecBuilder) Members(members ...*AppRoleMemberId) *RoleSpecBuilder {
+	if r.spec.Members == nil {
+		r.spec.Members = []*AppRoleMemberId{}
+	}
+	r.spec.Members = append(r.spec.Members, members...)
+	return r
+}
+
+// 添加一个协作者，memberType 为 open_id、union_id、user_id、chat_id 等
+func (r *RoleSpecBuilder) Member(memberType string, id string) *RoleSpecBuilder {
+	return r.Members(NewAppRoleMemberIdBuilder().Type(memberType).Id(id).Build())
+}
+
+func (r *RoleSpecBuilder) Build() (*RoleSpec, error) {
+	if err := r.spec.validate(); err != nil {
+		return nil, err
+	}
+	return r.spec, nil
+}
+
+// TableRoleSpecBuilder 构造数据表权限
+type TableRoleSpecBuilder struct {
+	spec *TableRoleSpec
+}
+
+// table 为数据表名，perm 取值见 TablePerm* 常量
+func NewTableRoleSpecBuilder(table string, perm int) *TableRoleSpecBuilder {
+	return &TableRoleSpecBuilder{spec: &TableRoleSpec{Table: table, Perm: perm}}
+}
+
+// 设置字段权限，取值见 FieldPerm* 常量，仅在数据表权限为可编辑时允许
+func (t *TableRoleSpecBuilder) FieldPerm(field string, perm int) *TableRoleSpecBuilder {
+	if t.spec.FieldPerms == nil {
+		t.spec.FieldPerms = map[string]int{}
+	}
+	t.spec.FieldPerms[field] = perm
+	return t
+}
+
+// 记录筛选条件，可由 RecRuleBuilder 生成
+func (t *TableRoleSpecBuilder) RecRule(recRule *AppRoleTableRoleRecRule) *TableRoleSpecBuilder {
+	t.spec.RecRule = recRule
+	return t
+}
+
+func (t *TableRoleSpecBuilder) AllowAddRecord(allow bool) *TableRoleSpecBuilder {
+	t.spec.AllowAddRecord = allow
+	return t
+}
+
+func (t *TableRoleSpecBuilder) AllowDeleteRecord(allow bool) *TableRoleSpecBuilder {
+	t.spec.AllowDeleteRecord = allow
+	return t
+}
+
+func (t *TableRoleSpecBuilder) Build() (*TableRoleSpec, error) {
+	if err := t.spec.validate(); err != nil {
+		return nil, err
+	}
+	return t.spec, nil
+}
+
+// RoleChange 计划中的一项变更
+type RoleChange struct {
+	Action   string           // 变更类型，取值见 RoleChange* 常量
+	RoleName string           // 角色名
+	RoleId   string           // 角色ID，新建的角色在执行前为空
+	Role     *AppRoleDetail   // 新建或更新时提交的完整角色
+	Diffs    []string         // 更新时的具体差异
+	Member   *AppRoleMemberId // 新增或删除的协作者
+}
+
+func (c *RoleChange) String() string {
+	switch c.Action {
+	case RoleChangeAddMember, RoleChangeRemoveMember:
+		return fmt.Sprintf("%s %s: %s:%s", c.Action, c.RoleName, *c.Member.Type, *c.Member.Id)
+	case RoleChangeUpdate:
+		return fmt.Sprintf("%s %s: %s", c.Action, c.RoleName, strings.Join(c.Diffs, "; "))
+	}
+	return fmt.Sprintf("%s %s", c.Action, c.RoleName)
+}
+
+// RolePlan 角色声明与多维表格现状的差异
+type RolePlan struct {
+	Changes []*RoleChange
+}
+
+func (p *RolePlan) String() string {
+	if len(p.Changes) == 0 {
+		return "no changes"
+	}
+	lines := make([]string, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		lines = append(lines, change.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// 对比角色声明与现有自定义角色，生成变更计划
+//
+// - 按角色名匹配；prune 为 true 时，删除未声明的角色
+//
+// - 更新角色接口是全量覆盖，因此更新时提交的是声明中的完整角色，Diffs 仅用于展示
+func (a *appRole) Plan(ctx context.Context, specs []*RoleSpec, prune bool, options ...larkcore.RequestOptionFunc) (*RolePlan, error) {
+	roles, err := a.ListAll(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]*AppRoleDetail{}
+	for _, role := range roles {
+		if role.RoleName != nil {
+			existing[*role.RoleName] = role
+		}
+	}
+
+	plan := &RolePlan{}
+	declared := map[string]bool{}
+	for _, spec := range specs {
+		declared[spec.Name] = true
+		desired := spec.appRole()
+		current, ok := existing[spec.Name]
+		roleId := ""
+		if !ok {
+			plan.Changes = append(plan.Changes, &RoleChange{Action: RoleChangeCreate, RoleName: spec.Name, Role: desired})
+		} else {
+			if roleId = stringValue(current.RoleId); roleId == "" {
+				return nil, fmt.Errorf("role %s listed without role id", spec.Name)
+			}
+			if diffs := diffAppRole(current, desired); len(diffs) > 0 {
+				plan.Changes = append(plan.Changes, &RoleChange{Action: RoleChangeUpdate, RoleName: spec.Name, RoleId: roleId, Role: desired, Diffs: diffs})
+			}
+		}
+
+		if spec.Members == nil {
+			continue
+		}
+		var members []*AppRoleMember
+		if roleId != "" {
+			if members, err = a.service.AppRoleMember.ListAll(ctx, roleId, options...); err != nil {
+				return nil, err
+			}
+		}
+		adds, removes := diffRoleMembers(members, spec.Members)
+		for _, member := range adds {
+			plan.Changes = append(plan.Changes, &RoleChange{Action: RoleChangeAddMember, RoleName: spec.Name, RoleId: roleId, Member: member})
+		}
+		for _, member := range removes {
+			plan.Changes = append(plan.Changes, &RoleChange{Action: RoleChangeRemoveMember, RoleName: spec.Name, RoleId: roleId, Member: member})
+		}
+	}
+
+	if prune {
+		for _, role := range roles {
+			if role.RoleName != nil && role.RoleId != nil && !declared[*role.RoleName] {
+				plan.Changes = append(plan.Changes, &RoleChange{Action: RoleChangeDelete, RoleName: *role.RoleName, RoleId: *role.RoleId})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// 按顺序执行变更计划
+//
+// - 新建角色后，同一计划中该角色的协作者变更使用新角色的ID
+//
+// - 协作者变更按角色合并后分批提交
+func (a *appRole) Apply(ctx context.Context, plan *RolePlan, options ...larkcore.RequestOptionFunc) error {
+	roleIds := map[string]string{}
+	adds := map[string][]*AppRoleMemberId{}
+	removes := map[string][]*AppRoleMemberId{}
+	var order []string
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case RoleChangeCreate:
+			resp, err := a.CreateDetail(ctx, change.Role, options...)
+			if err != nil {
+				return err
+			}
+			if !resp.Success() {
+				return resp.CodeError
+			}
+			if resp.Data != nil && resp.Data.Role != nil && resp.Data.Role.RoleId != nil {
+				change.RoleId = *resp.Data.Role.RoleId
+				roleIds[change.RoleName] = change.RoleId
+			}
+		case RoleChangeUpdate:
+			resp, err := a.UpdateDetail(ctx, change.RoleId, change.Role, options...)
+			if err != nil {
+				return err
+			}
+			if !resp.Success() {
+				return resp.CodeError
+			}
+		case RoleChangeDelete:
+			resp, err := a.Delete(ctx, NewDeleteAppRoleReqBuilder().RoleId(change.RoleId).Build(), options...)
+			if err != nil {
+				return err
+			}
+			if !resp.Success() {
+				return resp.CodeError
+			}
+		case RoleChangeAddMember, RoleChangeRemoveMember:
+			if change.RoleId == "" {
+				change.RoleId = roleIds[change.RoleName]
+			}
+			if change.RoleId == "" {
+				return fmt.Errorf("role %s has no role id, it must be created before changing members", change.RoleName)
+			}
+			if _, ok := adds[change.RoleId]; !ok {
+				if _, ok := removes[change.RoleId]; !ok {
+					order = append(order, change.RoleId)
+				}
+			}
+			if change.Action == RoleChangeAddMember {
+				adds[change.RoleId] = append(adds[change.RoleId], change.Member)
+			} else {
+				removes[change.RoleId] = append(removes[change.RoleId], change.Member)
+			}
+		}
+	}
+
+	for _, roleId := range order {
+		if err := a.service.AppRoleMember.BatchDeleteAll(ctx, roleId, removes[roleId], options...); err != nil {
+			return err
+		}
+		if err := a.service.AppRoleMember.BatchCreateAll(ctx, roleId, adds[roleId], options...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 转换为提交给接口的角色
+func (s *RoleSpec) appRole() *AppRoleDetail {
+	tableRoles := make([]*AppRoleTableRoleDetail, 0, len(s.Tables))
+	for _, table := range s.Tables {
+		builder := NewAppRoleTableRoleBuilder().
+			TableName(table.Table).
+			TablePerm(table.Perm)
+		if table.RecRule != nil {
+			builder.RecRule(table.RecRule)
+		}
+		tableRole := &AppRoleTableRoleDetail{}
+		if table.Perm == TablePermEdit {
+			if len(table.FieldPerms) > 0 {
+				tableRole.FieldPerm = make(map[string]int, len(table.FieldPerms))
+				for name, perm := range table.FieldPerms {
+					tableRole.FieldPerm[name] = perm
+				}
+			}
+			builder.AllowAddRecord(table.AllowAddRecord).AllowDeleteRecord(table.AllowDeleteRecord)
+		}
+		tableRole.AppRoleTableRole = builder.Build()
+		tableRoles = append(tableRoles, tableRole)
+	}
+	blockRoles := make([]*AppRoleBlockRole, 0, len(s.Blocks))
+	for _, block := range s.Blocks {
+		blockType := block.BlockType
+		if blockType == "" {
+			blockType = BlockTypeDashboard
+		}
+		blockRoles = append(blockRoles, NewAppRoleBlockRoleBuilder().
+			BlockId(block.BlockId).
+			BlockType(blockType).
+			BlockPerm(block.Perm).
+			Build())
+	}
+	return &AppRoleDetail{
+		AppRole: NewAppRoleBuilder().
+			RoleName(s.Name).
+			BlockRoles(blockRoles).
+			Build(),
+		TableRoles: tableRoles,
+	}
+}
+
+// 列出现有角色与期望角色的差异，按数据表名、block ID 对齐
+func diffAppRole(current *AppRoleDetail, desired *AppRoleDetail) []string {
+	var diffs []string
+	currentTables := map[string]*AppRoleTableRoleDetail{}
+	for _, table := range current.TableRoles {
+		if table.TableName != nil {
+			currentTables[*table.TableName] = table
+		}
+	}
+	desiredTables := map[string]bool{}
+	for _, table := range desired.TableRoles {
+		name := *table.TableName
+		desiredTables[name] = true
+		existing, ok := currentTables[name]
+		if !ok {
+			existing = &AppRoleTableRoleDetail{AppRoleTableRole: &AppRoleTableRole{}}
+		}
+		for _, diff := range diffTableRole(existing, table) {
+			diffs = append(diffs, fmt.Sprintf("table %s: %s", name, diff))
+		}
+	}
+	for _, table := range current.TableRoles {
+		if table.TableName != nil && !desiredTables[*table.TableName] && intValue(table.TablePerm) != TablePermNone {
+			diffs = append(diffs, fmt.Sprintf("table %s: table_perm %d -> %d", *table.TableName, intValue(table.TablePerm), TablePermNone))
+		}
+	}
+
+	currentBlocks := map[string]int{}
+	for _, block := range current.BlockRoles {
+		if block.BlockId != nil {
+			currentBlocks[*block.BlockId] = intValue(block.BlockPerm)
+		}
+	}
+	for _, block := range desired.BlockRoles {
+		if perm := currentBlocks[*block.BlockId]; perm != *block.BlockPerm {
+			diffs = append(diffs, fmt.Sprintf("block %s: block_perm %d -> %d", *block.BlockId, perm, *block.BlockPerm))
+		}
+		delete(currentBlocks, *block.BlockId)
+	}
+	for blockId, perm := range currentBlocks {
+		if perm != BlockPermNone {
+			diffs = append(diffs, fmt.Sprintf("block %s: block_perm %d -> %d", blockId, perm, BlockPermNone))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+func diffTableRole(current *AppRoleTableRoleDetail, desired *AppRoleTableRoleDetail) []string {
+	var diffs []string
+	if from, to := intValue(current.TablePerm), intValue(desired.TablePerm); from != to {
+		diffs = append(diffs, fmt.Sprintf("table_perm %d -> %d", from, to))
+	}
+	if from, to := boolValue(current.AllowAddRecord), boolValue(desired.AllowAddRecord); from != to {
+		diffs = append(diffs, fmt.Sprintf("allow_add_record %t -> %t", from, to))
+	}
+	if from, to := boolValue(current.AllowDeleteRecord), boolValue(desired.AllowDeleteRecord); from != to {
+		diffs = append(diffs, fmt.Sprintf("allow_delete_record %t -> %t", from, to))
+	}
+
+	currentFields, desiredFields := current.FieldPerm, desired.FieldPerm
+	names := map[string]bool{}
+	for name := range currentFields {
+		names[name] = true
+	}
+	for name := range desiredFields {
+		names[name] = true
+	}
+	for name := range names {
+		from, hasFrom := currentFields[name]
+		to, hasTo := desiredFields[name]
+		switch {
+		case !hasTo:
+			// 更新角色是全量覆盖，未声明的字段权限会被移除，恢复为沿用数据表权限
+			diffs = append(diffs, fmt.Sprintf("field %s: field_perm %d -> unset", name, from))
+		case !hasFrom || from != to:
+			diffs = append(diffs, fmt.Sprintf("field %s: field_perm %d -> %d", name, from, to))
+		}
+	}
+
+	if !reflect.DeepEqual(normalizeRecRule(current.RecRule), normalizeRecRule(desired.RecRule)) {
+		from, _ := json.Marshal(current.RecRule)
+		to, _ := json.Marshal(desired.RecRule)
+		diffs = append(diffs, fmt.Sprintf("rec_rule %s -> %s", from, to))
+	}
+	sort.Strings(diffs)
+	return diffs
+}
+
+// 记录筛选条件转换为可比较的形式，条件顺序和选项顺序不影响结果
+func normalizeRecRule(rule *AppRoleTableRoleRecRule) []string {
+	if rule == nil || len(rule.Conditions) == 0 {
+		return nil
+	}
+	conditions := make([]string, 0, len(rule.Conditions)+1)
+	conjunction := "and"
+	if rule.Conjunction != nil {
+		conjunction = *rule.Conjunction
+	}
+	conditions = append(conditions, fmt.Sprintf("conjunction=%s other_perm=%d", conjunction, intValue(rule.OtherPerm)))
+	for _, condition := range rule.Conditions {
+		values := append([]string{}, condition.Value...)
+		sort.Strings(values)
+		conditions = append(conditions, fmt.Sprintf("%s %s %s", stringValue(condition.FieldName), stringValue(condition.Operator), strings.Join(values, ",")))
+	}
+	sort.Strings(conditions[1:])
+	return conditions
+}
+
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func boolValue(v *bool) bool {
+	if v == nil {
+		return false
+	}
+	return *v
+}
+
+func stringValue(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,231 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestTableRole(perm int, fieldPerm map[string]int, recRule *AppRoleTableRoleRecRule) *AppRoleTableRoleDetail {
+	return &AppRoleTableRoleDetail{
+		AppRoleTableRole: NewAppRoleTableRoleBuilder().TableName("orders").TablePerm(perm).RecRule(recRule).Build(),
+		FieldPerm:        fieldPerm,
+	}
+}
+
+func newTestRecRule(conjunction string, conditions ...*AppRoleTableRoleRecRuleCondition) *AppRoleTableRoleRecRule {
+	return NewAppRoleTableRoleRecRuleBuilder().Conjunction(conjunction).Conditions(conditions).Build()
+}
+
+func newTestRecRuleCondition(fieldName string, values ...string) *AppRoleTableRoleRecRuleCondition {
+	return NewAppRoleTableRoleRecRuleConditionBuilder().FieldName(fieldName).Operator(RecRuleOperatorIs).Value(values).Build()
+}
+
+func TestDiffTableRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *AppRoleTableRoleDetail
+		desired *AppRoleTableRoleDetail
+		want    []string
+	}{
+		{
+			name:    "unchanged",
+			current: newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermRead}, nil),
+			desired: newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermRead}, nil),
+		},
+		{
+			name:    "table_perm",
+			current: newTestTableRole(TablePermRead, nil, nil),
+			desired: newTestTableRole(TablePermEdit, nil, nil),
+			want:    []string{"table_perm 1 -> 2"},
+		},
+		{
+			name:    "field_perm_added",
+			current: newTestTableRole(TablePermEdit, nil, nil),
+			desired: newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermRead}, nil),
+			want:    []string{"field Amount: field_perm 0 -> 1"},
+		},
+		{
+			name:    "field_perm_changed",
+			current: newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermRead}, nil),
+			desired: newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermEdit}, nil),
+			want:    []string{"field Amount: field_perm 1 -> 2"},
+		},
+		{
+			name:    "field_perm_removed",
+			current: newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermRead, "Status": FieldPermRead}, nil),
+			desired: newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermRead}, nil),
+			want:    []string{"field Status: field_perm 1 -> unset"},
+		},
+		{
+			name: "rec_rule_order_insensitive",
+			current: newTestTableRole(TablePermEdit, nil, newTestRecRule(RecRuleConjunctionAnd,
+				newTestRecRuleCondition("Owner", "opt_b", "opt_a"),
+				newTestRecRuleCondition("Status", "opt_c"))),
+			desired: newTestTableRole(TablePermEdit, nil, newTestRecRule(RecRuleConjunctionAnd,
+				newTestRecRuleCondition("Status", "opt_c"),
+				newTestRecRuleCondition("Owner", "opt_a", "opt_b"))),
+		},
+		{
+			name: "rec_rule_conjunction",
+			current: newTestTableRole(TablePermEdit, nil, newTestRecRule(RecRuleConjunctionAnd,
+				newTestRecRuleCondition("Status", "opt_c"))),
+			desired: newTestTableRole(TablePermEdit, nil, newTestRecRule(RecRuleConjunctionOr,
+				newTestRecRuleCondition("Status", "opt_c"))),
+			want: []string{`rec_rule {"conditions":[{"field_name":"Status","operator":"is","value":["opt_c"]}],"conjunction":"and"} -> {"conditions":[{"field_name":"Status","operator":"is","value":["opt_c"]}],"conjunction":"or"}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffTableRole(tt.current, tt.desired)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffTableRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffAppRole(t *testing.T) {
+	spec, err := NewRoleSpecBuilder("sales").
+		Table(&TableRoleSpec{Table: "orders", Perm: TablePermRead}).
+		Dashboard("blk_1", BlockPermRead).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := &AppRoleDetail{
+		AppRole: NewAppRoleBuilder().RoleName("sales").Build(),
+		TableRoles: []*AppRoleTableRoleDetail{
+			newTestTableRole(TablePermEdit, map[string]int{"Amount": FieldPermRead}, nil),
+			{AppRoleTableRole: NewAppRoleTableRoleBuilder().TableName("customers").TablePerm(TablePermRead).Build()},
+		},
+	}
+	want := []string{
+		"block blk_1: block_perm 0 -> 1",
+		"table customers: table_perm 1 -> 0",
+		"table orders: field Amount: field_perm 1 -> unset",
+		"table orders: table_perm 2 -> 1",
+	}
+	if got := diffAppRole(current, spec.appRole()); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffAppRole() = %q, want %q", got, want)
+	}
+}
+
+func TestRoleSpecBuilder(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func() (*RoleSpec, error)
+		wantErr string
+	}{
+		{
+			name: "valid",
+			build: func() (*RoleSpec, error) {
+				table, err := NewTableRoleSpecBuilder("orders", TablePermEdit).
+					FieldPerm("Amount", FieldPermRead).
+					AllowAddRecord(true).
+					Build()
+				if err != nil {
+					return nil, err
+				}
+				return NewRoleSpecBuilder("sales").Table(table).Member("open_id", "ou_1").Build()
+			},
+		},
+		{
+			name:    "no_name",
+			build:   NewRoleSpecBuilder("").Build,
+			wantErr: "role spec without name",
+		},
+		{
+			name: "duplicate_table",
+			build: NewRoleSpecBuilder("sales").
+				Table(&TableRoleSpec{Table: "orders", Perm: TablePermRead}).
+				Table(&TableRoleSpec{Table: "orders", Perm: TablePermEdit}).
+				Build,
+			wantErr: "duplicate table orders",
+		},
+		{
+			name: "invalid_table_perm",
+			build: func() (*RoleSpec, error) {
+				return NewRoleSpecBuilder("sales").Table(&TableRoleSpec{Table: "orders", Perm: 3}).Build()
+			},
+			wantErr: "invalid perm 3",
+		},
+		{
+			name: "field_perm_without_edit",
+			build: func() (*RoleSpec, error) {
+				_, err := NewTableRoleSpecBuilder("orders", TablePermRead).FieldPerm("Amount", FieldPermRead).Build()
+				return nil, err
+			},
+			wantErr: "field_perms require perm 2",
+		},
+		{
+			name: "invalid_field_perm",
+			build: func() (*RoleSpec, error) {
+				_, err := NewTableRoleSpecBuilder("orders", TablePermEdit).FieldPerm("Amount", 4).Build()
+				return nil, err
+			},
+			wantErr: "field Amount: invalid perm 4",
+		},
+		{
+			name:    "invalid_block_perm",
+			build:   NewRoleSpecBuilder("sales").Dashboard("blk_1", 2).Build,
+			wantErr: "block blk_1: invalid perm 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := tt.build()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Build() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if spec.Members == nil || len(spec.Members) != 1 {
+				t.Errorf("Build() members = %v", spec.Members)
+			}
+		})
+	}
+}
+
+func TestParseRoleSpecs(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "valid", data: `[{"name":"sales","tables":[{"table":"orders","perm":2,"field_perms":{"Amount":1}}]}]`},
+		{name: "duplicate_role", data: `[{"name":"sales","tables":[]},{"name":"sales","tables":[]}]`, wantErr: "duplicate role spec sales"},
+		{name: "add_record_without_edit", data: `[{"name":"sales","tables":[{"table":"orders","perm":1,"allow_add_record":true}]}]`, wantErr: "require perm 2"},
+		{name: "invalid_json", data: `{`, wantErr: "unexpected end of JSON input"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRoleSpecs([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseRoleSpecs() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const selectOptionSchemaTTL = time.Minute
+
+// DefaultOptionPalette 新建选项默认依次使用的颜色
+var DefaultOptionPalette = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+// SelectOptionCreator 在写入记录前为单选、多选字段补充缺少的选项
+//
+// - 按缓存的字段定义比对记录中的选项名，缺少时重新获取字段定义，并通过更新字段一次性追加该字段缺少的全部选项
+//
+// - 同一进程内，同一多维表格数据表的选项追加串行执行，避免并发写入互相覆盖字段的选项
+//
+// - 通过 Register 注册后总是先于 RecordValidator 调用；字段定义与同一 BaseService 的 RecordValidator 共享，追加的选项对校验立即可见
+type SelectOptionCreator struct {
+	service *BaseService
+	schemas *tableSchemaCache
+	palette []int
+	options []larkcore.RequestOptionFunc
+}
+
+// 按 appToken/tableId 串行化选项追加，不同的 BaseService、SelectOptionCreator 实例共享
+var (
+	selectOptionLocksMu sync.Mutex
+	selectOptionLocks   = map[string]*sync.Mutex{}
+)
+
+// 创建选项补充器，palette 为新选项依次使用的颜色，为空时使用 DefaultOptionPalette
+func (b *BaseService) NewSelectOptionCreator(palette []int, options ...larkcore.RequestOptionFunc) *SelectOptionCreator {
+	if len(palette) == 0 {
+		palette = DefaultOptionPalette
+	}
+	return &SelectOptionCreator{
+		service: b,
+		schemas: newTableSchemaCache(b, selectOptionSchemaTTL, options),
+		palette: palette,
+		options: options,
+	}
+}
+
+// 注册为创建补充器的 BaseService 的补全 hook，先于 RecordValidator 等校验 hook 调用
+func (c *SelectOptionCreator) Register() {
+	c.service.UseRecordFillHook(c.Ensure)
+}
+
+// 返回可通过 BaseService.UseRecordFillHook 注册的 hook
+func (c *SelectOptionCreator) Hook() RecordWriteHook {
+	return c.Ensure
+}
+
+// 为记录中出现但字段中不存在的选项名创建选项
+func (c *SelectOptionCreator) Ensure(ctx context.Context, appToken string, tableId string, records []*AppTableRecord) error {
+	schema, err := c.schemas.get(ctx, appToken, tableId)
+	if err != nil {
+		return err
+	}
+	if len(missingSelectOptions(schema, records)) == 0 {
+		return nil
+	}
+
+	lock := c.tableLock(appToken, tableId)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// 其他写入方可能已经追加了选项，以最新的字段定义为准
+	c.schemas.invalidate(appToken, tableId)
+	schema, err = c.schemas.get(ctx, appToken, tableId)
+	if err != nil {
+		return err
+	}
+	missing := missingSelectOptions(schema, records)
+	if len(missing) == 0 {
+		return nil
+	}
+	defer c.schemas.invalidate(appToken, tableId)
+	for _, field := range schema.fields {
+		names := missing[stringValue(field.FieldName)]
+		if len(names) == 0 {
+			continue
+		}
+		if err := c.addOptions(ctx, appToken, tableId, field, names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *SelectOptionCreator) tableLock(appToken string, tableId string) *sync.Mutex {
+	key := appToken + "/" + tableId
+	selectOptionLocksMu.Lock()
+	defer selectOptionLocksMu.Unlock()
+	lock, ok := selectOptionLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		selectOptionLocks[key] = lock
+	}
+	return lock
+}
+
+// 更新字段时需要提交完整的字段属性，在原有选项后追加新选项
+func (c *SelectOptionCreator) addOptions(ctx context.Context, appToken string, tableId string, field *AppTableField, names []string) error {
+	property := &AppTableFieldProperty{}
+	if field.Property != nil {
+		data, err := json.Marshal(field.Property)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, property); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		color := c.palette[len(property.Options)%len(c.palette)]
+		property.Options = append(property.Options, NewAppTableFieldPropertyOptionBuilder().
+			Name(name).
+			Color(color).
+			Build())
+	}
+	builder := NewUpdateAppTableFieldReqBuilder().
+		TableId(tableId).
+		FieldId(stringValue(field.FieldId)).
+		AppTableField(&AppTableField{
+			FieldName:   field.FieldName,
+			Type:        field.Type,
+			Property:    property,
+			Description: field.Description,
+			UiType:      field.UiType,
+		})
+	if appToken != "" {
+		builder.AppToken(appToken)
+	}
+	resp, err := c.service.AppTableField.Update(ctx, builder.Build(), c.options...)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return fmt.Errorf("add options to field %s: %w", stringValue(field.FieldName), resp.CodeError)
+	}
+	return nil
+}
+
+// 按字段名返回记录中出现但字段中不存在的选项名，保持首次出现的顺序
+func missingSelectOptions(schema *tableSchema, records []*AppTableRecord) map[string][]string {
+	missing := map[string][]string{}
+	seen := map[string]bool{}
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		for name, value := range record.Fields {
+			field, ok := schema.byName[name]
+			if !ok || field.Type == nil || (*field.Type != TypeSingleSelect && *field.Type != TypeMultiSelect) {
+				continue
+			}
+			for _, option := range selectOptionNames(value) {
+				key := name + "\x00" + option
+				if option == "" || seen[key] {
+					continue
+				}
+				seen[key] = true
+				if _, err := fieldOptionId(field, option); err != nil {
+					missing[name] = append(missing[name], option)
+				}
+			}
+		}
+	}
+	return missing
+}
+
+func selectOptionNames(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		var names []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+func TestMissingSelectOptions(t *testing.T) {
+	options := []*AppTableFieldPropertyOption{
+		NewAppTableFieldPropertyOptionBuilder().Id("opt1").Name("Open").Build(),
+	}
+	schema := &tableSchema{byName: map[string]*AppTableField{
+		"Status": NewAppTableFieldBuilder().FieldName("Status").Type(TypeSingleSelect).Property(NewAppTableFieldPropertyBuilder().Options(options).Build()).Build(),
+		"Labels": NewAppTableFieldBuilder().FieldName("Labels").Type(TypeMultiSelect).Property(NewAppTableFieldPropertyBuilder().Options(options).Build()).Build(),
+		"Title":  newTestField("Title", TypeText),
+	}}
+	tests := []struct {
+		name    string
+		records []*AppTableRecord
+		want    map[string][]string
+	}{
+		{
+			name:    "existing_options",
+			records: []*AppTableRecord{newTestRecord("rec1", map[string]interface{}{"Status": "Open", "Labels": []string{"Open"}})},
+			want:    map[string][]string{},
+		},
+		{
+			name: "single_and_multi_select",
+			records: []*AppTableRecord{
+				newTestRecord("rec1", map[string]interface{}{"Status": "Closed", "Labels": []interface{}{"Urgent", "Open", "Later"}}),
+			},
+			want: map[string][]string{"Status": {"Closed"}, "Labels": {"Urgent", "Later"}},
+		},
+		{
+			name: "deduplicated_in_first_seen_order",
+			records: []*AppTableRecord{
+				newTestRecord("rec1", map[string]interface{}{"Labels": []string{"B", "A"}}),
+				newTestRecord("rec2", map[string]interface{}{"Labels": []string{"A", "C"}}),
+			},
+			want: map[string][]string{"Labels": {"B", "A", "C"}},
+		},
+		{
+			name: "ignored_values",
+			records: []*AppTableRecord{
+				nil,
+				newTestRecord("rec1", map[string]interface{}{"Status": "", "Title": "New", "Missing": "New", "Labels": nil}),
+			},
+			want: map[string][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missingSelectOptions(schema, tt.records); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingSelectOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordWriteHookOrder(t *testing.T) {
+	service := NewService(&larkcore.Config{AppToken: "app"})
+	var calls []string
+	hook := func(name string) RecordWriteHook {
+		return func(ctx context.Context, appToken string, tableId string, records []*AppTableRecord) error {
+			calls = append(calls, name+":"+appToken+"/"+tableId)
+			return nil
+		}
+	}
+	service.UseRecordWriteHook(hook("validate"))
+	service.UseRecordFillHook(hook("fill1"))
+	service.UseRecordFillHook(hook("fill2"))
+
+	apiReq := &larkcore.ApiReq{PathParams: larkcore.PathParams{}, Body: NewAppTableRecordBuilder().Fields(map[string]interface{}{}).Build()}
+	apiReq.PathParams.Set("table_id", "tbl1")
+	if err := service.beforeRecordWrite(context.Background(), apiReq); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"fill1:app/tbl1", "fill2:app/tbl1", "validate:app/tbl1"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("hooks called in order %v, want %v", calls, want)
+	}
+}
+
+func TestSelectOptionCreator_SharedState(t *testing.T) {
+	first := NewService(&larkcore.Config{AppToken: "app"})
+	second := NewService(&larkcore.Config{AppToken: "app"})
+	other := NewService(&larkcore.Config{AppToken: "other"})
+
+	if first.NewSelectOptionCreator(nil).tableLock("app", "tbl1") != second.NewSelectOptionCreator(nil).tableLock("app", "tbl1") {
+		t.Error("creators of the same app should share the table lock")
+	}
+	if first.NewSelectOptionCreator(nil).tableLock("app", "tbl1") == other.NewSelectOptionCreator(nil).tableLock("other", "tbl1") {
+		t.Error("writes to different apps should not share the table lock")
+	}
+	if first.NewSelectOptionCreator(nil).schemas.store != first.NewRecordValidator().schemas.store {
+		t.Error("creator and validator of the same service should share the schema cache")
+	}
+}
+
+func TestSelectOptionCreator_Ensure(t *testing.T) {
+	server := newFakeBitableServer(t)
+	creator := server.service().NewSelectOptionCreator([]int{7, 8})
+	records := []*AppTableRecord{
+		newTestRecord("", map[string]interface{}{"Title": "a", "Tags": []string{"Red", "Urgent"}}),
+		newTestRecord("", map[string]interface{}{"Tags": []interface{}{"Later"}}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := creator.Ensure(context.Background(), "other", "tblOrders", records); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	updates := server.find("/tblOrders/fields/fld5")
+	if len(updates) != 1 {
+		t.Fatalf("updated the field %d times, want 1", len(updates))
+	}
+	if want := "/open-apis/bitable/v1/apps/other/tables/tblOrders/fields/fld5"; updates[0].path != want {
+		t.Errorf("updated %s, want %s", updates[0].path, want)
+	}
+	want := []interface{}{
+		map[string]interface{}{"id": "optRed", "name": "Red", "color": 5.0},
+		map[string]interface{}{"name": "Urgent", "color": 8.0},
+		map[string]interface{}{"name": "Later", "color": 7.0},
+	}
+	property, _ := updates[0].body["property"].(map[string]interface{})
+	if got := property["options"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("updated options %v, want %v", got, want)
+	}
+	if err := creator.Ensure(context.Background(), "other", "tblOrders", records); err != nil {
+		t.Fatal(err)
+	}
+	if updates := server.find("/fields/fld5"); len(updates) != 1 {
+		t.Errorf("updated the field again after the options were added")
+	}
+}
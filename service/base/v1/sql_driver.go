@@ -0,0 +1,863 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 批量新增、更新、删除记录接口单次支持的记录数上限
+const sqlDriverBatchLimit = 500
+
+// 基于当前客户端创建 database/sql 驱动连接，一般通过 sqldriver 子包以 sql.Open("bitable", dsn) 使用
+//
+// - SQL 中的表名为数据表名称或 table_id，列名为字段名，另有只读的 record_id 列
+//
+// - SELECT 仅支持单表，WHERE 转换为筛选公式（record_id 对应 RECORD_ID()）、ORDER BY 转换为排序参数、LIMIT/OFFSET 在翻页时处理
+//
+// - INSERT 对应新增记录；UPDATE、DELETE 的 WHERE 必须是 record_id = ? 或 record_id IN (...)
+//
+// - 不支持事务
+func (b *BaseService) SQLConn() driver.Conn {
+	return &sqlConn{service: b, tables: map[string]*sqlTable{}}
+}
+
+// sqlTable 数据表及其字段，按连接缓存
+type sqlTable struct {
+	tableId string
+	name    string
+	fields  []*AppTableField
+	index   map[string]*AppTableField // 小写字段名 -> 字段
+}
+
+type sqlConn struct {
+	service *BaseService
+
+	mu     sync.Mutex
+	tables map[string]*sqlTable // 小写表名或 table_id -> 表
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := parseSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	numInput, err := countSQLPlaceholders(query)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlStmt{conn: c, stmt: stmt, numInput: numInput}, nil
+}
+
+func (c *sqlConn) Close() error {
+	return nil
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("bitable: transactions are not supported")
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	stmt, err := parseSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.query(ctx, stmt, namedValues(args))
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	stmt, err := parseSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.exec(ctx, stmt, namedValues(args))
+}
+
+type sqlStmt struct {
+	conn     *sqlConn
+	stmt     interface{}
+	numInput int
+}
+
+func (s *sqlStmt) Close() error {
+	return nil
+}
+
+func (s *sqlStmt) NumInput() int {
+	return s.numInput
+}
+
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.conn.exec(context.Background(), s.stmt, values(args))
+}
+
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.conn.query(context.Background(), s.stmt, values(args))
+}
+
+func (s *sqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.exec(ctx, s.stmt, namedValues(args))
+}
+
+func (s *sqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.query(ctx, s.stmt, namedValues(args))
+}
+
+func values(args []driver.Value) []interface{} {
+	result := make([]interface{}, len(args))
+	for i, arg := range args {
+		result[i] = arg
+	}
+	return result
+}
+
+func namedValues(args []driver.NamedValue) []interface{} {
+	result := make([]interface{}, len(args))
+	for _, arg := range args {
+		if arg.Ordinal > 0 && arg.Ordinal <= len(result) {
+			result[arg.Ordinal-1] = arg.Value
+		}
+	}
+	return result
+}
+
+// 按表名或 table_id 查找数据表并加载字段
+func (c *sqlConn) table(ctx context.Context, name string) (*sqlTable, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if table, ok := c.tables[strings.ToLower(name)]; ok {
+		return table, nil
+	}
+
+	iterator, err := c.service.AppTable.ListByIterator(ctx, NewListAppTableReqBuilder().Build())
+	if err != nil {
+		return nil, err
+	}
+	var table *sqlTable
+	for table == nil {
+		hasMore, appTable, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return nil, fmt.Errorf("bitable: unknown table %s", name)
+		}
+		if appTable.TableId == nil || appTable.Name == nil {
+			continue
+		}
+		if *appTable.TableId == name || strings.EqualFold(*appTable.Name, name) {
+			table = &sqlTable{tableId: *appTable.TableId, name: *appTable.Name, index: map[string]*AppTableField{}}
+		}
+	}
+
+	if table.fields, err = c.service.AppTableField.ListAll(ctx, table.tableId); err != nil {
+		return nil, err
+	}
+	for _, field := range table.fields {
+		if field.FieldName != nil {
+			table.index[strings.ToLower(*field.FieldName)] = field
+		}
+	}
+	c.tables[strings.ToLower(name)] = table
+	return table, nil
+}
+
+func (t *sqlTable) field(name string) (*AppTableField, error) {
+	field, ok := t.index[strings.ToLower(name)]
+	if !ok || field.Type == nil {
+		return nil, fmt.Errorf("bitable: unknown column %s in table %s", name, t.name)
+	}
+	return field, nil
+}
+
+func (c *sqlConn) query(ctx context.Context, stmt interface{}, args []interface{}) (driver.Rows, error) {
+	selectStmt, ok := stmt.(*sqlSelect)
+	if !ok {
+		return nil, errors.New("bitable: Query only supports SELECT, use Exec for INSERT, UPDATE and DELETE")
+	}
+	if len(selectStmt.joins) > 0 || len(selectStmt.groupBy) > 0 || selectStmt.having != nil {
+		return nil, errors.New("bitable: JOIN, GROUP BY and HAVING are not supported, use Mirror for such queries")
+	}
+	table, err := c.table(ctx, selectStmt.from.name)
+	if err != nil {
+		return nil, err
+	}
+
+	// 输出列
+	// fieldNames 为 nil 表示获取全部字段，出现 * 后不再按列缩小范围
+	rows := &sqlRows{}
+	fieldNames := []string{}
+	star := false
+	for _, item := range selectStmt.items {
+		if item.star {
+			rows.columns = append(rows.columns, MirrorColumnRecordId)
+			rows.fields = append(rows.fields, nil)
+			for _, field := range table.fields {
+				if field.FieldName != nil && field.Type != nil {
+					rows.columns = append(rows.columns, *field.FieldName)
+					rows.fields = append(rows.fields, field)
+				}
+			}
+			star = true
+			continue
+		}
+		ref, ok := item.expr.(*sqlColumnRef)
+		if !ok {
+			return nil, fmt.Errorf("bitable: only columns can be selected, got %s", sqlExprString(item.expr))
+		}
+		name := ref.name
+		if item.alias != "" {
+			name = item.alias
+		}
+		rows.columns = append(rows.columns, name)
+		if strings.EqualFold(ref.name, MirrorColumnRecordId) {
+			rows.fields = append(rows.fields, nil)
+			continue
+		}
+		field, err := table.field(ref.name)
+		if err != nil {
+			return nil, err
+		}
+		rows.fields = append(rows.fields, field)
+		fieldNames = append(fieldNames, *field.FieldName)
+	}
+	if star {
+		fieldNames = nil
+	}
+
+	records, err := c.selectRecords(ctx, table, selectStmt, fieldNames, args)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		row := make([]driver.Value, len(rows.columns))
+		for i, field := range rows.fields {
+			if field == nil {
+				if record.RecordId != nil {
+					row[i] = *record.RecordId
+				}
+				continue
+			}
+			row[i] = sqlDriverValue(*field.Type, record.Fields[*field.FieldName])
+		}
+		rows.rows = append(rows.rows, row)
+	}
+	return rows, nil
+}
+
+func (c *sqlConn) selectRecords(ctx context.Context, table *sqlTable, stmt *sqlSelect, fieldNames []string, args []interface{}) ([]*AppTableRecord, error) {
+	builder := NewListAppTableRecordReqBuilder().TableId(table.tableId)
+	if stmt.where != nil {
+		filter, err := sqlFilterFormula(stmt.where, table, args)
+		if err != nil {
+			return nil, err
+		}
+		builder.Filter(filter)
+	}
+	if len(stmt.orderBy) > 0 {
+		var sorts []string
+		for _, order := range stmt.orderBy {
+			ref, ok := order.expr.(*sqlColumnRef)
+			if !ok {
+				return nil, fmt.Errorf("bitable: ORDER BY only supports columns, got %s", sqlExprString(order.expr))
+			}
+			field, err := table.field(ref.name)
+			if err != nil {
+				return nil, err
+			}
+			direction := "ASC"
+			if order.desc {
+				direction = "DESC"
+			}
+			sorts = append(sorts, *field.FieldName+" "+direction)
+		}
+		sort, err := json.Marshal(sorts)
+		if err != nil {
+			return nil, err
+		}
+		builder.Sort(string(sort))
+	}
+	if fieldNames != nil {
+		names, err := json.Marshal(fieldNames)
+		if err != nil {
+			return nil, err
+		}
+		builder.FieldNames(string(names))
+	}
+
+	// OFFSET 在遍历时跳过，LIMIT 交给迭代器控制总数
+	offset := 0
+	if stmt.offset != nil {
+		var err error
+		if offset, err = sqlCount(stmt.offset, args); err != nil {
+			return nil, err
+		}
+	}
+	if stmt.limit != nil {
+		limit, err := sqlCount(stmt.limit, args)
+		if err != nil {
+			return nil, err
+		}
+		if limit == 0 {
+			return nil, nil
+		}
+		builder.Limit(offset + limit)
+	}
+
+	iterator, err := c.service.AppTableRecord.ListByIterator(ctx, builder.Build())
+	if err != nil {
+		return nil, err
+	}
+	var records []*AppTableRecord
+	for skipped := 0; ; {
+		hasMore, record, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return records, nil
+		}
+		if skipped < offset {
+			skipped++
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+func sqlCount(expr sqlExpr, args []interface{}) (int, error) {
+	value, err := evalSQL(expr, nil, args)
+	if err != nil {
+		return 0, err
+	}
+	number, ok := sqlNumber(value)
+	if !ok || number < 0 {
+		return 0, fmt.Errorf("bitable: LIMIT and OFFSET must be non-negative numbers, got %v", value)
+	}
+	return int(number), nil
+}
+
+func (c *sqlConn) exec(ctx context.Context, stmt interface{}, args []interface{}) (driver.Result, error) {
+	switch s := stmt.(type) {
+	case *sqlInsert:
+		return c.insert(ctx, s, args)
+	case *sqlUpdate:
+		return c.update(ctx, s, args)
+	case *sqlDelete:
+		return c.delete(ctx, s, args)
+	}
+	return nil, errors.New("bitable: Exec only supports INSERT, UPDATE and DELETE, use Query for SELECT")
+}
+
+func (c *sqlConn) insert(ctx context.Context, stmt *sqlInsert, args []interface{}) (driver.Result, error) {
+	table, err := c.table(ctx, stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*AppTableRecord, 0, len(stmt.rows))
+	for _, row := range stmt.rows {
+		fields := map[string]interface{}{}
+		for i, column := range stmt.columns {
+			if err := sqlAssign(table, fields, column, row[i], args); err != nil {
+				return nil, err
+			}
+		}
+		records = append(records, NewAppTableRecordBuilder().Fields(fields).Build())
+	}
+
+	var affected int64
+	for start := 0; start < len(records); start += sqlDriverBatchLimit {
+		end := start + sqlDriverBatchLimit
+		if end > len(records) {
+			end = len(records)
+		}
+		resp, err := c.service.AppTableRecord.BatchCreate(ctx, NewBatchCreateAppTableRecordReqBuilder().
+			TableId(table.tableId).
+			Body(NewBatchCreateAppTableRecordReqBodyBuilder().
+				Records(records[start:end]).
+				Build()).
+			Build())
+		if err != nil {
+			return driver.RowsAffected(affected), err
+		}
+		if !resp.Success() {
+			return driver.RowsAffected(affected), resp.CodeError
+		}
+		if resp.Data != nil {
+			affected += int64(len(resp.Data.Records))
+		}
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+func (c *sqlConn) update(ctx context.Context, stmt *sqlUpdate, args []interface{}) (driver.Result, error) {
+	table, err := c.table(ctx, stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	recordIds, err := sqlRecordIds(stmt.where, args)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	for _, set := range stmt.set {
+		if err := sqlAssign(table, fields, set.column, set.value, args); err != nil {
+			return nil, err
+		}
+	}
+
+	var affected int64
+	for start := 0; start < len(recordIds); start += sqlDriverBatchLimit {
+		end := start + sqlDriverBatchLimit
+		if end > len(recordIds) {
+			end = len(recordIds)
+		}
+		records := make([]*AppTableRecord, 0, end-start)
+		for _, recordId := range recordIds[start:end] {
+			records = append(records, NewAppTableRecordBuilder().RecordId(recordId).Fields(fields).Build())
+		}
+		resp, err := c.service.AppTableRecord.BatchUpdate(ctx, NewBatchUpdateAppTableRecordReqBuilder().
+			TableId(table.tableId).
+			Body(NewBatchUpdateAppTableRecordReqBodyBuilder().
+				Records(records).
+				Build()).
+			Build())
+		if err != nil {
+			return driver.RowsAffected(affected), err
+		}
+		if !resp.Success() {
+			return driver.RowsAffected(affected), resp.CodeError
+		}
+		if resp.Data != nil {
+			affected += int64(len(resp.Data.Records))
+		}
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+func (c *sqlConn) delete(ctx context.Context, stmt *sqlDelete, args []interface{}) (driver.Result, error) {
+	table, err := c.table(ctx, stmt.table)
+	if err != nil {
+		return nil, err
+	}
+	recordIds, err := sqlRecordIds(stmt.where, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var affected int64
+	for start := 0; start < len(recordIds); start += sqlDriverBatchLimit {
+		end := start + sqlDriverBatchLimit
+		if end > len(recordIds) {
+			end = len(recordIds)
+		}
+		resp, err := c.service.AppTableRecord.BatchDelete(ctx, NewBatchDeleteAppTableRecordReqBuilder().
+			TableId(table.tableId).
+			Body(NewBatchDeleteAppTableRecordReqBodyBuilder().
+				Records(recordIds[start:end]).
+				Build()).
+			Build())
+		if err != nil {
+			return driver.RowsAffected(affected), err
+		}
+		if !resp.Success() {
+			return driver.RowsAffected(affected), resp.CodeError
+		}
+		if resp.Data == nil {
+			continue
+		}
+		for _, record := range resp.Data.Records {
+			if record.Deleted != nil && *record.Deleted {
+				affected++
+			}
+		}
+	}
+	return driver.RowsAffected(affected), nil
+}
+
+// 从 record_id = ? 或 record_id IN (...) 中取出记录ID
+func sqlRecordIds(where sqlExpr, args []interface{}) ([]string, error) {
+	unsupported := errors.New("bitable: WHERE must be record_id = ? or record_id IN (...)")
+	var ref sqlExpr
+	var list []sqlExpr
+	switch e := where.(type) {
+	case *sqlBinary:
+		if e.op != "=" {
+			return nil, unsupported
+		}
+		ref, list = e.left, []sqlExpr{e.right}
+	case *sqlIn:
+		if e.not {
+			return nil, unsupported
+		}
+		ref, list = e.expr, e.list
+	default:
+		return nil, unsupported
+	}
+	if column, ok := ref.(*sqlColumnRef); !ok || !strings.EqualFold(column.name, MirrorColumnRecordId) {
+		return nil, unsupported
+	}
+	recordIds := make([]string, 0, len(list))
+	for _, item := range list {
+		value, err := evalSQL(item, nil, args)
+		if err != nil {
+			return nil, err
+		}
+		recordId, ok := value.(string)
+		if !ok || recordId == "" {
+			return nil, fmt.Errorf("bitable: invalid record_id %v", value)
+		}
+		recordIds = append(recordIds, recordId)
+	}
+	return recordIds, nil
+}
+
+// 将 WHERE 转换为记录筛选公式
+func sqlFilterFormula(expr sqlExpr, table *sqlTable, args []interface{}) (string, error) {
+	switch e := expr.(type) {
+	case *sqlBinary:
+		switch e.op {
+		case "AND", "OR":
+			left, err := sqlFilterFormula(e.left, table, args)
+			if err != nil {
+				return "", err
+			}
+			right, err := sqlFilterFormula(e.right, table, args)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s(%s, %s)", e.op, left, right), nil
+		case "=", "!=", "<", "<=", ">", ">=", "LIKE":
+			column, value, op, err := sqlFilterOperands(e, table, args)
+			if err != nil {
+				return "", err
+			}
+			if op != "LIKE" {
+				return fmt.Sprintf("%s%s%s", column, op, value), nil
+			}
+			pattern, ok := e.right.(*sqlLiteral)
+			var s string
+			if ok {
+				s, ok = pattern.value.(string)
+			} else if p, isPlaceholder := e.right.(*sqlPlaceholder); isPlaceholder && p.index < len(args) {
+				s, ok = args[p.index].(string)
+			}
+			if !ok {
+				return "", errors.New("bitable: LIKE pattern must be a string")
+			}
+			inner := strings.TrimSuffix(strings.TrimPrefix(s, "%"), "%")
+			if strings.ContainsAny(inner, "%_") {
+				return "", fmt.Errorf("bitable: LIKE only supports '%%text%%' or exact patterns, got %q", s)
+			}
+			if inner == s {
+				return fmt.Sprintf("%s=%s", column, sqlFormulaString(s)), nil
+			}
+			return fmt.Sprintf("%s.contains(%s)", column, sqlFormulaString(inner)), nil
+		}
+	case *sqlUnary:
+		if e.op == "NOT" {
+			inner, err := sqlFilterFormula(e.expr, table, args)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("NOT(%s)", inner), nil
+		}
+	case *sqlIsNull:
+		ref, ok := e.expr.(*sqlColumnRef)
+		if !ok {
+			break
+		}
+		field, err := table.field(ref.name)
+		if err != nil {
+			return "", err
+		}
+		formula := fmt.Sprintf("CurrentValue.[%s]=\"\"", *field.FieldName)
+		if e.not {
+			formula = fmt.Sprintf("NOT(%s)", formula)
+		}
+		return formula, nil
+	case *sqlIn:
+		items := make([]string, 0, len(e.list))
+		for _, item := range e.list {
+			formula, err := sqlFilterFormula(&sqlBinary{op: "=", left: e.expr, right: item}, table, args)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formula)
+		}
+		formula := fmt.Sprintf("OR(%s)", strings.Join(items, ", "))
+		if e.not {
+			formula = fmt.Sprintf("NOT(%s)", formula)
+		}
+		return formula, nil
+	}
+	return "", fmt.Errorf("bitable: unsupported WHERE condition %s", sqlExprString(expr))
+}
+
+// 比较的一侧必须是列，另一侧是常量或占位符
+func sqlFilterOperands(e *sqlBinary, table *sqlTable, args []interface{}) (string, string, string, error) {
+	left, right, op := e.left, e.right, e.op
+	if _, ok := left.(*sqlColumnRef); !ok {
+		left, right = right, left
+		switch op {
+		case "<":
+			op = ">"
+		case "<=":
+			op = ">="
+		case ">":
+			op = "<"
+		case ">=":
+			op = "<="
+		}
+	}
+	ref, ok := left.(*sqlColumnRef)
+	if !ok {
+		return "", "", "", fmt.Errorf("bitable: unsupported WHERE condition %s", sqlExprString(e))
+	}
+	// record_id 转换为 RECORD_ID() 函数，与其它条件一样交给筛选公式
+	if strings.EqualFold(ref.name, MirrorColumnRecordId) {
+		if op != "=" && op != "!=" {
+			return "", "", "", fmt.Errorf("bitable: record_id only supports = and != in %s", sqlExprString(e))
+		}
+		value, err := evalSQL(right, nil, args)
+		if err != nil {
+			return "", "", "", err
+		}
+		recordId, ok := value.(string)
+		if !ok {
+			return "", "", "", fmt.Errorf("bitable: invalid record_id %v", value)
+		}
+		return "RECORD_ID()", sqlFormulaString(recordId), op, nil
+	}
+	field, err := table.field(ref.name)
+	if err != nil {
+		return "", "", "", err
+	}
+	if _, ok := right.(*sqlColumnRef); ok {
+		return "", "", "", fmt.Errorf("bitable: comparing two columns is not supported in %s", sqlExprString(e))
+	}
+	value, err := evalSQL(right, nil, args)
+	if err != nil {
+		return "", "", "", err
+	}
+	column := fmt.Sprintf("CurrentValue.[%s]", *field.FieldName)
+	switch v := value.(type) {
+	case nil:
+		return column, `""`, op, nil
+	case string:
+		return column, sqlFormulaString(v), op, nil
+	case bool:
+		if v {
+			return column, "TRUE()", op, nil
+		}
+		return column, "FALSE()", op, nil
+	case time.Time:
+		return column, fmt.Sprint(v.UnixMilli()), op, nil
+	}
+	if number, ok := sqlNumber(value); ok {
+		return column, sqlString(number), op, nil
+	}
+	return "", "", "", fmt.Errorf("bitable: unsupported value %v", value)
+}
+
+func sqlFormulaString(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
+
+// 按字段类型将写入的值转换为单元格
+func sqlAssign(table *sqlTable, fields map[string]interface{}, column string, expr sqlExpr, args []interface{}) error {
+	if strings.EqualFold(column, MirrorColumnRecordId) {
+		return errors.New("bitable: record_id is read only")
+	}
+	field, err := table.field(column)
+	if err != nil {
+		return err
+	}
+	value, err := evalSQL(expr, nil, args)
+	if err != nil {
+		return err
+	}
+	cell, err := sqlCellValue(*field.Type, value)
+	if err != nil {
+		return fmt.Errorf("bitable: column %s: %v", *field.FieldName, err)
+	}
+	fields[*field.FieldName] = cell
+	return nil
+}
+
+func sqlCellValue(fieldType int, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	switch fieldType {
+	case TypeFormula, TypeCreatedTime, TypeModifiedTime, TypeCreatedUser, TypeModifiedUser, TypeAutoSerial:
+		return nil, errors.New("field is read only")
+	case TypeNumber:
+		if number, ok := sqlNumber(value); ok {
+			return number, nil
+		}
+	case TypeDateTime:
+		if t, ok := value.(time.Time); ok {
+			return t.UnixMilli(), nil
+		}
+		if number, ok := sqlNumber(value); ok {
+			return int64(number), nil
+		}
+	case TypeCheckbox:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+		if number, ok := sqlNumber(value); ok {
+			return number != 0, nil
+		}
+	case TypeMultiSelect, TypeLink, TypeDuplexLink:
+		return sqlStringList(value), nil
+	case TypeUser, TypeGroupChat:
+		ids := sqlStringList(value)
+		cells := make([]map[string]interface{}, 0, len(ids))
+		for _, id := range ids {
+			cells = append(cells, map[string]interface{}{"id": id})
+		}
+		return cells, nil
+	case TypeUrl:
+		s := sqlString(value)
+		return map[string]interface{}{"link": s, "text": s}, nil
+	default:
+		return sqlString(value), nil
+	}
+	return nil, fmt.Errorf("invalid value %v", value)
+}
+
+// 多值字段的写入值：JSON 字符串数组，或单个值
+func sqlStringList(value interface{}) []string {
+	s := sqlString(value)
+	var list []string
+	if strings.HasPrefix(strings.TrimSpace(s), "[") && json.Unmarshal([]byte(s), &list) == nil {
+		return list
+	}
+	return []string{s}
+}
+
+// 将单元格转换为 driver.Value，多值字段编码为 JSON 字符串数组
+func sqlDriverValue(fieldType int, cell interface{}) driver.Value {
+	value := mirrorCellValue(fieldType, cell)
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case float64:
+		if fieldType == TypeDateTime || fieldType == TypeCreatedTime || fieldType == TypeModifiedTime {
+			return time.UnixMilli(int64(v))
+		}
+		return v
+	case bool, string:
+		return v
+	case []string:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return sqlString(value)
+}
+
+var sqlFieldTypeNames = map[int]string{
+	TypeText:         "TEXT",
+	TypeNumber:       "NUMBER",
+	TypeSingleSelect: "SINGLE_SELECT",
+	TypeMultiSelect:  "MULTI_SELECT",
+	TypeDateTime:     "DATETIME",
+	TypeCheckbox:     "CHECKBOX",
+	TypeUser:         "USER",
+	TypeUrl:          "URL",
+	TypeAttachment:   "ATTACHMENT",
+	TypeLink:         "LINK",
+	TypeFormula:      "FORMULA",
+	TypeDuplexLink:   "DUPLEX_LINK",
+	TypeCreatedTime:  "CREATED_TIME",
+	TypeModifiedTime: "MODIFIED_TIME",
+	TypeCreatedUser:  "CREATED_USER",
+	TypeModifiedUser: "MODIFIED_USER",
+	TypeAutoSerial:   "AUTO_SERIAL",
+	TypePhoneNumber:  "PHONE_NUMBER",
+	TypeLocation:     "LOCATION",
+	TypeGroupChat:    "GROUP_CHAT",
+}
+
+// sqlRows 查询结果，fields 与 columns 一一对应，record_id 列为 nil
+type sqlRows struct {
+	columns []string
+	fields  []*AppTableField
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *sqlRows) Columns() []string {
+	return r.columns
+}
+
+func (r *sqlRows) Close() error {
+	return nil
+}
+
+func (r *sqlRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+// 列的字段类型名，如 TEXT、NUMBER、DATETIME；record_id 列为 RECORD_ID
+func (r *sqlRows) ColumnTypeDatabaseTypeName(index int) string {
+	field := r.fields[index]
+	if field == nil {
+		return "RECORD_ID"
+	}
+	if name, ok := sqlFieldTypeNames[*field.Type]; ok {
+		return name
+	}
+	return fmt.Sprintf("TYPE_%d", *field.Type)
+}
+
+func (r *sqlRows) ColumnTypeScanType(index int) reflect.Type {
+	field := r.fields[index]
+	if field == nil {
+		return reflect.TypeOf("")
+	}
+	switch *field.Type {
+	case TypeNumber:
+		return reflect.TypeOf(sql.NullFloat64{})
+	case TypeCheckbox:
+		return reflect.TypeOf(false)
+	case TypeDateTime, TypeCreatedTime, TypeModifiedTime:
+		return reflect.TypeOf(sql.NullTime{})
+	}
+	return reflect.TypeOf(sql.NullString{})
+}
+
+func (r *sqlRows) ColumnTypeNullable(index int) (bool, bool) {
+	return r.fields[index] != nil, true
+}
@@ -0,0 +1,462 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 测试用的多维表格接口，记录收到的请求并按路径返回固定响应
+type fakeBitableServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	requests    []*fakeBitableRequest
+	records     []map[string]interface{}            // 列出记录接口返回的记录
+	roleMembers map[string][]map[string]interface{} // 角色ID -> 列出协作者接口返回的协作者
+	form        map[string]interface{}              // 获取表单接口返回的表单
+	formFields  []map[string]interface{}            // 列出表单问题接口返回的问题
+	fields      []map[string]interface{}            // 列出字段接口返回的字段，更新字段接口会修改
+}
+
+type fakeBitableRequest struct {
+	method string
+	path   string
+	query  url.Values
+	body   map[string]interface{}
+}
+
+func newFakeBitableServer(t *testing.T) *fakeBitableServer {
+	f := &fakeBitableServer{fields: []map[string]interface{}{
+		{"field_id": "fld1", "field_name": "Title", "type": TypeText},
+		{"field_id": "fld2", "field_name": "Amount", "type": TypeNumber},
+		{"field_id": "fld3", "field_name": "Paid", "type": TypeCheckbox},
+		{"field_id": "fld4", "field_name": "Due", "type": TypeDateTime},
+		{"field_id": "fld5", "field_name": "Tags", "type": TypeMultiSelect, "property": map[string]interface{}{
+			"options": []interface{}{map[string]interface{}{"id": "optRed", "name": "Red", "color": 5}},
+		}},
+		{"field_id": "fld6", "field_name": "Owner", "type": TypeUser},
+		{"field_id": "fld7", "field_name": "Modified", "type": TypeModifiedTime},
+	}}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &fakeBitableRequest{method: r.Method, path: r.URL.Path, query: r.URL.Query()}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &request.body); err != nil {
+				t.Errorf("invalid request body %s", data)
+			}
+		}
+		f.mu.Lock()
+		f.requests = append(f.requests, request)
+		f.mu.Unlock()
+
+		var data interface{}
+		// 其它多维表格的请求按相同的路径返回固定响应
+		route := r.URL.Path
+		if rest := strings.TrimPrefix(route, "/open-apis/bitable/v1/apps/"); rest != route {
+			if i := strings.Index(rest, "/"); i >= 0 {
+				route = "/open-apis/bitable/v1/apps/app" + rest[i:]
+			}
+		}
+		prefix := "/open-apis/bitable/v1/apps/app/tables"
+		rolePrefix := "/open-apis/bitable/v1/apps/app/roles/"
+		switch path := strings.TrimPrefix(route, prefix); {
+		case strings.HasPrefix(route, rolePrefix):
+			// 协作者的列出、批量新增和批量删除，写操作只记录请求
+			role := strings.TrimPrefix(route, rolePrefix)
+			if roleId := strings.TrimSuffix(role, "/members"); roleId != role {
+				data = map[string]interface{}{"items": f.roleMembers[roleId]}
+			}
+		case path == "":
+			data = map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{"table_id": "tblOrders", "name": "Orders"},
+			}}
+		case path == "/tblOrders/fields":
+			f.mu.Lock()
+			data = map[string]interface{}{"items": append([]map[string]interface{}(nil), f.fields...)}
+			f.mu.Unlock()
+		case strings.HasPrefix(path, "/tblOrders/fields/") && r.Method == http.MethodPut:
+			// 与接口一致，为新选项分配 ID；复制一份，保留记录的请求原样
+			var field map[string]interface{}
+			raw, _ := json.Marshal(request.body)
+			json.Unmarshal(raw, &field)
+			field["field_id"] = strings.TrimPrefix(path, "/tblOrders/fields/")
+			if property, ok := field["property"].(map[string]interface{}); ok {
+				options, _ := property["options"].([]interface{})
+				for i, option := range options {
+					if option := option.(map[string]interface{}); option["id"] == nil {
+						option["id"] = fmt.Sprintf("opt%d", i)
+					}
+				}
+			}
+			f.mu.Lock()
+			for i, existing := range f.fields {
+				if existing["field_id"] == field["field_id"] {
+					f.fields[i] = field
+				}
+			}
+			f.mu.Unlock()
+			data = map[string]interface{}{"field": field}
+		case strings.HasPrefix(path, "/tblOrders/forms/"):
+			if strings.HasSuffix(path, "/fields") {
+				data = map[string]interface{}{"items": f.formFields}
+			} else {
+				data = map[string]interface{}{"form": f.form}
+			}
+		case path == "/tblOrders/records" && r.Method == http.MethodPost:
+			data = map[string]interface{}{"record": map[string]interface{}{"record_id": "recNew", "fields": request.body["fields"]}}
+		case path == "/tblOrders/records":
+			data = map[string]interface{}{"items": f.records}
+		case path == "/tblOrders/records/batch_create", path == "/tblOrders/records/batch_update":
+			data = map[string]interface{}{"records": request.body["records"]}
+		case path == "/tblOrders/records/batch_delete":
+			var records []interface{}
+			for _, recordId := range request.body["records"].([]interface{}) {
+				records = append(records, map[string]interface{}{"record_id": recordId, "deleted": recordId != "recMissing"})
+			}
+			data = map[string]interface{}{"records": records}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"code": 0, "msg": "success", "data": data})
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeBitableServer) service() *BaseService {
+	config := &larkcore.Config{BaseUrl: f.URL, PersonalBaseToken: "token", AppToken: "app"}
+	larkcore.NewLogger(config)
+	larkcore.NewSerialization(config)
+	larkcore.NewHttpClient(config)
+	return NewService(config)
+}
+
+// 返回路径以 suffix 结尾的请求
+func (f *fakeBitableServer) find(suffix string) []*fakeBitableRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var requests []*fakeBitableRequest
+	for _, request := range f.requests {
+		if strings.HasSuffix(request.path, suffix) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+func TestParseSQL_DML(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  interface{}
+	}{
+		{
+			name:  "insert",
+			query: "INSERT INTO Orders (Title, `Amount`) VALUES ('a', 1), (?, ?)",
+			want: &sqlInsert{table: "Orders", columns: []string{"Title", "Amount"}, rows: [][]sqlExpr{
+				{&sqlLiteral{value: "a"}, &sqlLiteral{value: 1.0}},
+				{&sqlPlaceholder{index: 0}, &sqlPlaceholder{index: 1}},
+			}},
+		},
+		{
+			name:  "update",
+			query: "update Orders set Title = 'b', Amount = ? where record_id = ?",
+			want: &sqlUpdate{table: "Orders",
+				set: []*sqlAssignment{
+					{column: "Title", value: &sqlLiteral{value: "b"}},
+					{column: "Amount", value: &sqlPlaceholder{index: 0}},
+				},
+				where: &sqlBinary{op: "=", left: &sqlColumnRef{name: "record_id"}, right: &sqlPlaceholder{index: 1}},
+			},
+		},
+		{
+			name:  "delete",
+			query: "DELETE FROM Orders WHERE record_id IN ('rec1', 'rec2');",
+			want: &sqlDelete{table: "Orders", where: &sqlIn{
+				expr: &sqlColumnRef{name: "record_id"},
+				list: []sqlExpr{&sqlLiteral{value: "rec1"}, &sqlLiteral{value: "rec2"}},
+			}},
+		},
+		{
+			name:  "delete_without_where",
+			query: "DELETE FROM Orders",
+			want:  &sqlDelete{table: "Orders"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSQL(tt.query)
+			if err != nil {
+				t.Fatalf("parseSQL(%q) error = %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSQL(%q) = %#v, want %#v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	for _, query := range []string{
+		"INSERT INTO Orders (Title, Amount) VALUES ('a')",
+		"INSERT INTO Orders VALUES ('a')",
+		"UPDATE Orders Title = 'a'",
+		"DELETE Orders",
+	} {
+		if _, err := parseSQL(query); err == nil {
+			t.Errorf("parseSQL(%q) expected an error", query)
+		}
+	}
+}
+
+func TestCountSQLPlaceholders(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "SELECT * FROM t", want: 0},
+		{query: "SELECT * FROM t WHERE a = ? AND b = '?'", want: 1},
+		{query: "INSERT INTO t (a, b) VALUES (?, ?), (?, ?)", want: 4},
+	}
+	for _, tt := range tests {
+		got, err := countSQLPlaceholders(tt.query)
+		if err != nil || got != tt.want {
+			t.Errorf("countSQLPlaceholders(%q) = %d, %v, want %d", tt.query, got, err, tt.want)
+		}
+	}
+}
+
+func TestSQLConn_Exec(t *testing.T) {
+	due := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    string
+		args     []interface{}
+		path     string
+		want     []interface{}
+		affected int64
+		wantErr  bool
+	}{
+		{
+			name:  "insert",
+			query: "INSERT INTO Orders (Title, Amount, Paid, Due, Tags, Owner) VALUES ('a', 1.5, TRUE, ?, ?, 'ou_1'), ('b', ?, 0, NULL, 'x', ?)",
+			args:  []interface{}{due, `["x","y"]`, int64(2), "ou_2"},
+			path:  "/records/batch_create",
+			want: []interface{}{
+				map[string]interface{}{"fields": map[string]interface{}{
+					"Title": "a", "Amount": 1.5, "Paid": true, "Due": float64(due.UnixMilli()),
+					"Tags": []interface{}{"x", "y"}, "Owner": []interface{}{map[string]interface{}{"id": "ou_1"}},
+				}},
+				map[string]interface{}{"fields": map[string]interface{}{
+					"Title": "b", "Amount": 2.0, "Paid": false, "Due": nil,
+					"Tags": []interface{}{"x"}, "Owner": []interface{}{map[string]interface{}{"id": "ou_2"}},
+				}},
+			},
+			affected: 2,
+		},
+		{
+			name:  "insert_by_table_id",
+			query: "INSERT INTO tblOrders (Title) VALUES ('c')",
+			path:  "/records/batch_create",
+			want: []interface{}{
+				map[string]interface{}{"fields": map[string]interface{}{"Title": "c"}},
+			},
+			affected: 1,
+		},
+		{
+			name:    "update_with_column_value",
+			query:   "UPDATE orders SET Title = ?, Amount = Amount WHERE record_id IN (?, 'rec2')",
+			args:    []interface{}{"renamed", "rec1"},
+			wantErr: true,
+		},
+		{
+			name:  "update_by_record_ids",
+			query: "UPDATE Orders SET Title = ?, Paid = FALSE WHERE record_id IN (?, 'rec2')",
+			args:  []interface{}{"renamed", "rec1"},
+			path:  "/records/batch_update",
+			want: []interface{}{
+				map[string]interface{}{"record_id": "rec1", "fields": map[string]interface{}{"Title": "renamed", "Paid": false}},
+				map[string]interface{}{"record_id": "rec2", "fields": map[string]interface{}{"Title": "renamed", "Paid": false}},
+			},
+			affected: 2,
+		},
+		{
+			name:     "delete",
+			query:    "DELETE FROM Orders WHERE record_id IN (?, 'recMissing')",
+			args:     []interface{}{"rec1"},
+			path:     "/records/batch_delete",
+			want:     []interface{}{"rec1", "recMissing"},
+			affected: 1,
+		},
+		{name: "update_requires_record_id", query: "UPDATE Orders SET Title = 'a' WHERE Title = 'b'", wantErr: true},
+		{name: "delete_requires_where", query: "DELETE FROM Orders", wantErr: true},
+		{name: "read_only_field", query: "INSERT INTO Orders (Modified) VALUES (1)", wantErr: true},
+		{name: "read_only_record_id", query: "UPDATE Orders SET record_id = 'a' WHERE record_id = 'rec1'", wantErr: true},
+		{name: "unknown_column", query: "INSERT INTO Orders (Missing) VALUES (1)", wantErr: true},
+		{name: "unknown_table", query: "INSERT INTO Missing (Title) VALUES (1)", wantErr: true},
+		{name: "invalid_number", query: "INSERT INTO Orders (Amount) VALUES ('abc')", wantErr: true},
+		{name: "select_is_not_exec", query: "SELECT * FROM Orders", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeBitableServer(t)
+			conn := server.service().SQLConn().(driver.ExecerContext)
+			args := make([]driver.NamedValue, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+			}
+			result, err := conn.ExecContext(context.Background(), tt.query, args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ExecContext(%q) expected an error", tt.query)
+				}
+				for _, request := range server.requests {
+					if strings.Contains(request.path, "/records/batch_") {
+						t.Fatalf("ExecContext(%q) wrote records on error", tt.query)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExecContext(%q) error = %v", tt.query, err)
+			}
+			requests := server.find(tt.path)
+			if len(requests) != 1 {
+				t.Fatalf("got %d requests to %s, want 1", len(requests), tt.path)
+			}
+			if got := requests[0].body["records"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("records = %#v, want %#v", got, tt.want)
+			}
+			if affected, _ := result.RowsAffected(); affected != tt.affected {
+				t.Errorf("RowsAffected() = %d, want %d", affected, tt.affected)
+			}
+		})
+	}
+}
+
+func TestSQLConn_Query(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		args       []interface{}
+		filter     string
+		sort       string
+		fieldNames string
+		columns    []string
+	}{
+		{
+			name:       "columns",
+			query:      "SELECT record_id, Title AS t FROM Orders WHERE Amount > ? AND Paid = TRUE ORDER BY Amount DESC",
+			args:       []interface{}{10},
+			filter:     "AND(CurrentValue.[Amount]>10, CurrentValue.[Paid]=TRUE())",
+			sort:       `["Amount DESC"]`,
+			fieldNames: `["Title"]`,
+			columns:    []string{"record_id", "t"},
+		},
+		{
+			name:    "star_with_extra_column",
+			query:   "SELECT *, Amount FROM Orders",
+			columns: []string{"record_id", "Title", "Amount", "Paid", "Due", "Tags", "Owner", "Modified", "Amount"},
+		},
+		{
+			name:    "column_before_star",
+			query:   "SELECT Amount, * FROM Orders",
+			columns: []string{"Amount", "record_id", "Title", "Amount", "Paid", "Due", "Tags", "Owner", "Modified"},
+		},
+		{
+			name:       "only_record_id",
+			query:      "SELECT record_id FROM Orders",
+			fieldNames: `[]`,
+			columns:    []string{"record_id"},
+		},
+		{
+			name:       "record_id_filter",
+			query:      "SELECT Title FROM Orders WHERE record_id IN (?, 'rec2') ORDER BY Title LIMIT 1",
+			args:       []interface{}{"rec1"},
+			filter:     `OR(RECORD_ID()="rec1", RECORD_ID()="rec2")`,
+			sort:       `["Title ASC"]`,
+			fieldNames: `["Title"]`,
+			columns:    []string{"Title"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeBitableServer(t)
+			conn := server.service().SQLConn().(driver.QueryerContext)
+			args := make([]driver.NamedValue, len(tt.args))
+			for i, arg := range tt.args {
+				args[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+			}
+			rows, err := conn.QueryContext(context.Background(), tt.query, args)
+			if err != nil {
+				t.Fatalf("QueryContext(%q) error = %v", tt.query, err)
+			}
+			if got := rows.Columns(); !reflect.DeepEqual(got, tt.columns) {
+				t.Errorf("Columns() = %v, want %v", got, tt.columns)
+			}
+			requests := server.find("/records")
+			if len(requests) != 1 {
+				t.Fatalf("got %d list requests, want 1", len(requests))
+			}
+			query := requests[0].query
+			if got := query.Get("filter"); got != tt.filter {
+				t.Errorf("filter = %s, want %s", got, tt.filter)
+			}
+			if got := query.Get("sort"); got != tt.sort {
+				t.Errorf("sort = %s, want %s", got, tt.sort)
+			}
+			if got := query.Get("field_names"); got != tt.fieldNames {
+				t.Errorf("field_names = %s, want %s", got, tt.fieldNames)
+			}
+		})
+	}
+}
+
+func TestSQLConn_QueryRows(t *testing.T) {
+	server := newFakeBitableServer(t)
+	server.records = []map[string]interface{}{
+		{"record_id": "rec1", "fields": map[string]interface{}{"Title": []interface{}{map[string]interface{}{"text": "a"}}, "Amount": 1.5, "Paid": true}},
+		{"record_id": "rec2", "fields": map[string]interface{}{"Title": "b"}},
+		{"record_id": "rec3", "fields": map[string]interface{}{"Title": "c"}},
+	}
+	conn := server.service().SQLConn().(driver.QueryerContext)
+	rows, err := conn.QueryContext(context.Background(), "SELECT record_id, Title, Amount, Paid FROM Orders LIMIT 1 OFFSET 1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]driver.Value
+	for {
+		row := make([]driver.Value, 4)
+		if err := rows.Next(row); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, row)
+	}
+	if want := [][]driver.Value{{"rec2", "b", nil, nil}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,479 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// 本文件实现 SQL 表达式求值，NULL 参与的比较结果为 NULL，在 WHERE 中按假处理
+
+// sqlEnv 表达式求值时的列取值与聚合计算
+type sqlEnv interface {
+	column(ref *sqlColumnRef) (interface{}, error)
+	aggregate(call *sqlCall) (interface{}, error)
+}
+
+var sqlAggregates = map[string]bool{"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true}
+
+// 表达式中是否包含聚合函数
+func sqlHasAggregate(expr sqlExpr) bool {
+	switch e := expr.(type) {
+	case *sqlCall:
+		if sqlAggregates[e.name] {
+			return true
+		}
+		for _, arg := range e.args {
+			if sqlHasAggregate(arg) {
+				return true
+			}
+		}
+	case *sqlBinary:
+		return sqlHasAggregate(e.left) || sqlHasAggregate(e.right)
+	case *sqlUnary:
+		return sqlHasAggregate(e.expr)
+	case *sqlIsNull:
+		return sqlHasAggregate(e.expr)
+	case *sqlIn:
+		if sqlHasAggregate(e.expr) {
+			return true
+		}
+		for _, item := range e.list {
+			if sqlHasAggregate(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func evalSQL(expr sqlExpr, env sqlEnv, args []interface{}) (interface{}, error) {
+	switch e := expr.(type) {
+	case *sqlLiteral:
+		return e.value, nil
+	case *sqlPlaceholder:
+		if e.index >= len(args) {
+			return nil, fmt.Errorf("sql: missing argument for placeholder %d", e.index+1)
+		}
+		return normalizeSQLValue(args[e.index]), nil
+	case *sqlColumnRef:
+		if env == nil {
+			return nil, fmt.Errorf("sql: column %s is not allowed here", e.name)
+		}
+		return env.column(e)
+	case *sqlUnary:
+		value, err := evalSQL(e.expr, env, args)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, nil
+		}
+		if e.op == "NOT" {
+			return !sqlTruthy(value), nil
+		}
+		number, ok := sqlNumber(value)
+		if !ok {
+			return nil, fmt.Errorf("sql: cannot negate %v", value)
+		}
+		return -number, nil
+	case *sqlIsNull:
+		value, err := evalSQL(e.expr, env, args)
+		if err != nil {
+			return nil, err
+		}
+		return sqlIsEmpty(value) != e.not, nil
+	case *sqlIn:
+		value, err := evalSQL(e.expr, env, args)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, nil
+		}
+		for _, item := range e.list {
+			candidate, err := evalSQL(item, env, args)
+			if err != nil {
+				return nil, err
+			}
+			if sqlEqual(value, candidate) {
+				return !e.not, nil
+			}
+		}
+		return e.not, nil
+	case *sqlCall:
+		if sqlAggregates[e.name] {
+			if env == nil {
+				return nil, fmt.Errorf("sql: aggregate %s is not allowed here", e.name)
+			}
+			return env.aggregate(e)
+		}
+		return evalSQLFunction(e, env, args)
+	case *sqlBinary:
+		return evalSQLBinary(e, env, args)
+	}
+	return nil, fmt.Errorf("sql: unsupported expression %T", expr)
+}
+
+func evalSQLBinary(e *sqlBinary, env sqlEnv, args []interface{}) (interface{}, error) {
+	left, err := evalSQL(e.left, env, args)
+	if err != nil {
+		return nil, err
+	}
+	// AND、OR 短路求值
+	switch e.op {
+	case "AND":
+		if left != nil && !sqlTruthy(left) {
+			return false, nil
+		}
+	case "OR":
+		if left != nil && sqlTruthy(left) {
+			return true, nil
+		}
+	}
+	right, err := evalSQL(e.right, env, args)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.op {
+	case "AND", "OR":
+		if right != nil && sqlTruthy(right) == (e.op == "OR") {
+			return e.op == "OR", nil
+		}
+		if left == nil || right == nil {
+			return nil, nil
+		}
+		// 此时 AND 两侧均为真，OR 两侧均为假
+		return e.op == "AND", nil
+	}
+	if left == nil || right == nil {
+		return nil, nil
+	}
+	switch e.op {
+	case "=":
+		return sqlEqual(left, right), nil
+	case "!=":
+		return !sqlEqual(left, right), nil
+	case "<", "<=", ">", ">=":
+		cmp, ok := sqlCompare(left, right)
+		if !ok {
+			return nil, nil
+		}
+		switch e.op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		}
+		return cmp >= 0, nil
+	case "LIKE":
+		pattern, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("sql: LIKE pattern must be a string")
+		}
+		for _, value := range sqlScalars(left) {
+			if sqlLike(strings.ToLower(sqlString(value)), strings.ToLower(pattern)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	a, aok := sqlNumber(left)
+	b, bok := sqlNumber(right)
+	if !aok || !bok {
+		return nil, fmt.Errorf("sql: operator %s requires numbers, got %v and %v", e.op, left, right)
+	}
+	switch e.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return nil, nil
+		}
+		return a / b, nil
+	}
+	return nil, fmt.Errorf("sql: unsupported operator %s", e.op)
+}
+
+func evalSQLFunction(e *sqlCall, env sqlEnv, args []interface{}) (interface{}, error) {
+	values := make([]interface{}, 0, len(e.args))
+	for _, arg := range e.args {
+		value, err := evalSQL(arg, env, args)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	switch e.name {
+	case "COALESCE":
+		for _, value := range values {
+			if !sqlIsEmpty(value) {
+				return value, nil
+			}
+		}
+		return nil, nil
+	case "LOWER", "UPPER", "LENGTH":
+		if len(values) != 1 {
+			return nil, fmt.Errorf("sql: %s expects 1 argument", e.name)
+		}
+		if values[0] == nil {
+			return nil, nil
+		}
+		s := sqlString(values[0])
+		switch e.name {
+		case "LOWER":
+			return strings.ToLower(s), nil
+		case "UPPER":
+			return strings.ToUpper(s), nil
+		}
+		if list, ok := values[0].([]string); ok {
+			return float64(len(list)), nil
+		}
+		return float64(utf8.RuneCountInString(s)), nil
+	}
+	return nil, fmt.Errorf("sql: unsupported function %s", e.name)
+}
+
+// 计算一组行上的聚合函数
+func evalSQLAggregate(call *sqlCall, envs []sqlEnv, args []interface{}) (interface{}, error) {
+	if call.star {
+		if call.name != "COUNT" {
+			return nil, fmt.Errorf("sql: %s(*) is not supported", call.name)
+		}
+		return float64(len(envs)), nil
+	}
+	if len(call.args) != 1 {
+		return nil, fmt.Errorf("sql: %s expects 1 argument", call.name)
+	}
+	var values []interface{}
+	seen := map[string]bool{}
+	for _, env := range envs {
+		value, err := evalSQL(call.args[0], env, args)
+		if err != nil {
+			return nil, err
+		}
+		if sqlIsEmpty(value) {
+			continue
+		}
+		if call.distinct {
+			key := sqlKey(value)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		values = append(values, value)
+	}
+
+	switch call.name {
+	case "COUNT":
+		return float64(len(values)), nil
+	case "SUM", "AVG":
+		if len(values) == 0 {
+			return nil, nil
+		}
+		sum := 0.0
+		for _, value := range values {
+			number, ok := sqlNumber(value)
+			if !ok {
+				return nil, fmt.Errorf("sql: %s requires numbers, got %v", call.name, value)
+			}
+			sum += number
+		}
+		if call.name == "AVG" {
+			return sum / float64(len(values)), nil
+		}
+		return sum, nil
+	}
+	var result interface{}
+	for _, value := range values {
+		if result == nil {
+			result = value
+			continue
+		}
+		cmp, ok := sqlCompare(value, result)
+		if ok && (cmp < 0) == (call.name == "MIN") && cmp != 0 {
+			result = value
+		}
+	}
+	return result, nil
+}
+
+// 将调用方传入的参数统一为 string、float64、bool 或 nil
+func normalizeSQLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int8:
+		return float64(v)
+	case int16:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint:
+		return float64(v)
+	case uint8:
+		return float64(v)
+	case uint16:
+		return float64(v)
+	case uint32:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	case []byte:
+		return string(v)
+	}
+	return value
+}
+
+func sqlNumber(value interface{}) (float64, bool) {
+	switch v := normalizeSQLValue(value).(type) {
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func sqlTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []string:
+		return len(v) > 0
+	}
+	number, ok := sqlNumber(value)
+	return !ok || number != 0
+}
+
+func sqlIsEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
+
+func sqlString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, ",")
+	case float64:
+		return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%f", v), "0"), ".")
+	}
+	return fmt.Sprint(value)
+}
+
+// 多值（多选、人员、关联等）展开为单个值，用于等值与 LIKE 匹配
+func sqlScalars(value interface{}) []interface{} {
+	if list, ok := value.([]string); ok {
+		values := make([]interface{}, len(list))
+		for i, item := range list {
+			values[i] = item
+		}
+		return values
+	}
+	return []interface{}{value}
+}
+
+// 多值与单值比较时，包含即相等，这使关联字段可以直接与 record_id 做 JOIN
+func sqlEqual(a, b interface{}) bool {
+	for _, x := range sqlScalars(a) {
+		for _, y := range sqlScalars(b) {
+			if cmp, ok := sqlCompare(x, y); ok && cmp == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sqlCompare(a, b interface{}) (int, bool) {
+	if x, ok := sqlNumber(a); ok {
+		if y, ok := sqlNumber(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if a == nil || b == nil {
+		return 0, false
+	}
+	return strings.Compare(sqlString(a), sqlString(b)), true
+}
+
+// 用于分组和去重的值的唯一表示
+func sqlKey(value interface{}) string {
+	return fmt.Sprintf("%T:%s", value, sqlString(value))
+}
+
+// LIKE 匹配，% 匹配任意个字符，_ 匹配单个字符
+func sqlLike(s string, pattern string) bool {
+	sr, pr := []rune(s), []rune(pattern)
+	// 回溯位置：最近一个 % 在 pattern 中的位置及其对应的 s 位置
+	si, pi, star, match := 0, 0, -1, 0
+	for si < len(sr) {
+		switch {
+		case pi < len(pr) && (pr[pi] == '_' || pr[pi] == sr[si]):
+			si++
+			pi++
+		case pi < len(pr) && pr[pi] == '%':
+			star, match = pi, si
+			pi++
+		case star >= 0:
+			pi = star + 1
+			match++
+			si = match
+		default:
+			return false
+		}
+	}
+	for pi < len(pr) && pr[pi] == '%' {
+		pi++
+	}
+	return pi == len(pr)
+}
@@ -0,0 +1,851 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 本文件实现本地镜像查询与 database/sql 驱动共用的 SQL 子集解析
+
+type sqlTokenKind int
+
+const (
+	sqlTokenEOF sqlTokenKind = iota
+	sqlTokenIdent
+	sqlTokenKeyword
+	sqlTokenString
+	sqlTokenNumber
+	sqlTokenSymbol
+	sqlTokenPlaceholder
+)
+
+var sqlKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "AND": true, "OR": true, "NOT": true,
+	"AS": true, "JOIN": true, "INNER": true, "LEFT": true, "OUTER": true, "ON": true,
+	"GROUP": true, "BY": true, "ORDER": true, "ASC": true, "DESC": true, "LIMIT": true,
+	"OFFSET": true, "LIKE": true, "IN": true, "IS": true, "NULL": true, "TRUE": true,
+	"FALSE": true, "INSERT": true, "INTO": true, "VALUES": true, "UPDATE": true, "SET": true,
+	"DELETE": true, "DISTINCT": true, "HAVING": true,
+}
+
+type sqlToken struct {
+	kind  sqlTokenKind
+	text  string // 关键字为大写，其它为原文（字符串与带引号的标识符已去掉引号）
+	pos   int
+	index int // 占位符序号，从 0 开始
+}
+
+func sqlTokenize(query string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	runes := []rune(query)
+	placeholders := 0
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '\'':
+			var sb strings.Builder
+			start := i
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("sql: unterminated string at %d", start)
+				}
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						sb.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenString, text: sb.String(), pos: start})
+		case r == '`' || r == '"' || r == '[':
+			closing := r
+			if r == '[' {
+				closing = ']'
+			}
+			start := i
+			i++
+			for i < len(runes) && runes[i] != closing {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("sql: unterminated identifier at %d", start)
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenIdent, text: string(runes[start+1 : i]), pos: start})
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenNumber, text: string(runes[start:i]), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			text := string(runes[start:i])
+			if sqlKeywords[strings.ToUpper(text)] {
+				tokens = append(tokens, sqlToken{kind: sqlTokenKeyword, text: strings.ToUpper(text), pos: start})
+			} else {
+				tokens = append(tokens, sqlToken{kind: sqlTokenIdent, text: text, pos: start})
+			}
+		case r == '?':
+			tokens = append(tokens, sqlToken{kind: sqlTokenPlaceholder, text: "?", pos: i, index: placeholders})
+			placeholders++
+			i++
+		default:
+			if i+1 < len(runes) {
+				two := string(runes[i : i+2])
+				if two == "<=" || two == ">=" || two == "!=" || two == "<>" {
+					tokens = append(tokens, sqlToken{kind: sqlTokenSymbol, text: two, pos: i})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("=<>(),.*+-/;", r) {
+				return nil, fmt.Errorf("sql: unexpected character %q at %d", r, i)
+			}
+			tokens = append(tokens, sqlToken{kind: sqlTokenSymbol, text: string(r), pos: i})
+			i++
+		}
+	}
+	return append(tokens, sqlToken{kind: sqlTokenEOF, pos: len(runes)}), nil
+}
+
+type sqlExpr interface{}
+
+type sqlColumnRef struct {
+	table string
+	name  string
+}
+
+type sqlLiteral struct {
+	value interface{} // nil、string、float64 或 bool
+}
+
+type sqlPlaceholder struct {
+	index int
+}
+
+type sqlBinary struct {
+	op    string // AND、OR、=、!=、<、<=、>、>=、LIKE、+、-、*、/
+	left  sqlExpr
+	right sqlExpr
+}
+
+type sqlUnary struct {
+	op   string // NOT 或 -
+	expr sqlExpr
+}
+
+type sqlIsNull struct {
+	expr sqlExpr
+	not  bool
+}
+
+type sqlIn struct {
+	expr sqlExpr
+	list []sqlExpr
+	not  bool
+}
+
+type sqlCall struct {
+	name     string // 大写函数名
+	args     []sqlExpr
+	star     bool
+	distinct bool
+}
+
+type sqlSelectItem struct {
+	expr      sqlExpr
+	alias     string
+	star      bool
+	starTable string
+}
+
+type sqlTableRef struct {
+	name  string
+	alias string
+}
+
+type sqlJoin struct {
+	table sqlTableRef
+	on    sqlExpr
+	left  bool
+}
+
+type sqlOrder struct {
+	expr sqlExpr
+	desc bool
+}
+
+type sqlSelect struct {
+	items   []*sqlSelectItem
+	from    sqlTableRef
+	joins   []*sqlJoin
+	where   sqlExpr
+	groupBy []sqlExpr
+	having  sqlExpr
+	orderBy []*sqlOrder
+	limit   sqlExpr
+	offset  sqlExpr
+}
+
+type sqlAssignment struct {
+	column string
+	value  sqlExpr
+}
+
+type sqlInsert struct {
+	table   string
+	columns []string
+	rows    [][]sqlExpr
+}
+
+type sqlUpdate struct {
+	table string
+	set   []*sqlAssignment
+	where sqlExpr
+}
+
+type sqlDelete struct {
+	table string
+	where sqlExpr
+}
+
+type sqlParser struct {
+	tokens []sqlToken
+	pos    int
+}
+
+// 解析单条 SQL 语句，返回 *sqlSelect、*sqlInsert、*sqlUpdate 或 *sqlDelete
+func parseSQL(query string) (interface{}, error) {
+	tokens, err := sqlTokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &sqlParser{tokens: tokens}
+	var stmt interface{}
+	switch {
+	case p.isKeyword("SELECT"):
+		stmt, err = p.parseSelect()
+	case p.isKeyword("INSERT"):
+		stmt, err = p.parseInsert()
+	case p.isKeyword("UPDATE"):
+		stmt, err = p.parseUpdate()
+	case p.isKeyword("DELETE"):
+		stmt, err = p.parseDelete()
+	default:
+		return nil, p.errorf("expected SELECT, INSERT, UPDATE or DELETE")
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.acceptSymbol(";")
+	if p.peek().kind != sqlTokenEOF {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+	return stmt, nil
+}
+
+// 统计语句中的占位符个数
+func countSQLPlaceholders(query string) (int, error) {
+	tokens, err := sqlTokenize(query)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, token := range tokens {
+		if token.kind == sqlTokenPlaceholder {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (p *sqlParser) peek() sqlToken {
+	return p.tokens[p.pos]
+}
+
+func (p *sqlParser) next() sqlToken {
+	token := p.tokens[p.pos]
+	if token.kind != sqlTokenEOF {
+		p.pos++
+	}
+	return token
+}
+
+func (p *sqlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("sql: %s at %d", fmt.Sprintf(format, args...), p.peek().pos)
+}
+
+func (p *sqlParser) isKeyword(keyword string) bool {
+	token := p.peek()
+	return token.kind == sqlTokenKeyword && token.text == keyword
+}
+
+func (p *sqlParser) acceptKeyword(keyword string) bool {
+	if p.isKeyword(keyword) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) expectKeyword(keyword string) error {
+	if !p.acceptKeyword(keyword) {
+		return p.errorf("expected %s", keyword)
+	}
+	return nil
+}
+
+func (p *sqlParser) isSymbol(symbol string) bool {
+	token := p.peek()
+	return token.kind == sqlTokenSymbol && token.text == symbol
+}
+
+func (p *sqlParser) acceptSymbol(symbol string) bool {
+	if p.isSymbol(symbol) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) expectSymbol(symbol string) error {
+	if !p.acceptSymbol(symbol) {
+		return p.errorf("expected %q", symbol)
+	}
+	return nil
+}
+
+func (p *sqlParser) parseIdent() (string, error) {
+	token := p.peek()
+	if token.kind != sqlTokenIdent {
+		return "", p.errorf("expected identifier")
+	}
+	p.pos++
+	return token.text, nil
+}
+
+func (p *sqlParser) parseSelect() (*sqlSelect, error) {
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+	stmt := &sqlSelect{}
+	for {
+		item, err := p.parseSelectItem()
+		if err != nil {
+			return nil, err
+		}
+		stmt.items = append(stmt.items, item)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	from, err := p.parseTableRef()
+	if err != nil {
+		return nil, err
+	}
+	stmt.from = from
+	for {
+		join := &sqlJoin{}
+		if p.acceptKeyword("LEFT") {
+			p.acceptKeyword("OUTER")
+			join.left = true
+		} else {
+			p.acceptKeyword("INNER")
+		}
+		if !p.acceptKeyword("JOIN") {
+			if join.left {
+				return nil, p.errorf("expected JOIN")
+			}
+			break
+		}
+		if join.table, err = p.parseTableRef(); err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("ON"); err != nil {
+			return nil, err
+		}
+		if join.on, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+		stmt.joins = append(stmt.joins, join)
+	}
+
+	if p.acceptKeyword("WHERE") {
+		if stmt.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("GROUP") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			stmt.groupBy = append(stmt.groupBy, expr)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+	if p.acceptKeyword("HAVING") {
+		if stmt.having, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.acceptKeyword("ORDER") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			order := &sqlOrder{expr: expr}
+			if p.acceptKeyword("DESC") {
+				order.desc = true
+			} else {
+				p.acceptKeyword("ASC")
+			}
+			stmt.orderBy = append(stmt.orderBy, order)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+	}
+	if p.acceptKeyword("LIMIT") {
+		if stmt.limit, err = p.parsePrimary(); err != nil {
+			return nil, err
+		}
+		if p.acceptKeyword("OFFSET") {
+			if stmt.offset, err = p.parsePrimary(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stmt, nil
+}
+
+func (p *sqlParser) parseSelectItem() (*sqlSelectItem, error) {
+	if p.acceptSymbol("*") {
+		return &sqlSelectItem{star: true}, nil
+	}
+	// table.*
+	if p.peek().kind == sqlTokenIdent && p.pos+2 < len(p.tokens) && p.tokens[p.pos+1].text == "." && p.tokens[p.pos+2].text == "*" {
+		table := p.next().text
+		p.pos += 2
+		return &sqlSelectItem{star: true, starTable: table}, nil
+	}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	item := &sqlSelectItem{expr: expr}
+	if p.acceptKeyword("AS") {
+		if item.alias, err = p.parseIdent(); err != nil {
+			return nil, err
+		}
+	} else if p.peek().kind == sqlTokenIdent {
+		item.alias = p.next().text
+	}
+	return item, nil
+}
+
+func (p *sqlParser) parseTableRef() (sqlTableRef, error) {
+	name, err := p.parseIdent()
+	if err != nil {
+		return sqlTableRef{}, err
+	}
+	ref := sqlTableRef{name: name}
+	if p.acceptKeyword("AS") {
+		if ref.alias, err = p.parseIdent(); err != nil {
+			return ref, err
+		}
+	} else if p.peek().kind == sqlTokenIdent {
+		ref.alias = p.next().text
+	}
+	return ref, nil
+}
+
+func (p *sqlParser) parseInsert() (*sqlInsert, error) {
+	if err := p.expectKeyword("INSERT"); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("INTO"); err != nil {
+		return nil, err
+	}
+	table, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &sqlInsert{table: table}
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	for {
+		column, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		stmt.columns = append(stmt.columns, column)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("VALUES"); err != nil {
+		return nil, err
+	}
+	for {
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		var row []sqlExpr
+		for {
+			value, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, value)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		if len(row) != len(stmt.columns) {
+			return nil, p.errorf("expected %d values, got %d", len(stmt.columns), len(row))
+		}
+		stmt.rows = append(stmt.rows, row)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	return stmt, nil
+}
+
+func (p *sqlParser) parseUpdate() (*sqlUpdate, error) {
+	if err := p.expectKeyword("UPDATE"); err != nil {
+		return nil, err
+	}
+	table, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &sqlUpdate{table: table}
+	if err := p.expectKeyword("SET"); err != nil {
+		return nil, err
+	}
+	for {
+		column, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectSymbol("="); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		stmt.set = append(stmt.set, &sqlAssignment{column: column, value: value})
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	if p.acceptKeyword("WHERE") {
+		if stmt.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+func (p *sqlParser) parseDelete() (*sqlDelete, error) {
+	if err := p.expectKeyword("DELETE"); err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	table, err := p.parseIdent()
+	if err != nil {
+		return nil, err
+	}
+	stmt := &sqlDelete{table: table}
+	if p.acceptKeyword("WHERE") {
+		if stmt.where, err = p.parseExpr(); err != nil {
+			return nil, err
+		}
+	}
+	return stmt, nil
+}
+
+// 运算符优先级从低到高：OR、AND、NOT、比较、加减、乘除、一元负号
+func (p *sqlParser) parseExpr() (sqlExpr, error) {
+	return p.parseOr()
+}
+
+func (p *sqlParser) parseOr() (sqlExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseAnd() (sqlExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseNot() (sqlExpr, error) {
+	if p.acceptKeyword("NOT") {
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &sqlUnary{op: "NOT", expr: expr}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *sqlParser) parseComparison() (sqlExpr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	token := p.peek()
+	if token.kind == sqlTokenSymbol {
+		switch token.text {
+		case "=", "!=", "<>", "<", "<=", ">", ">=":
+			p.pos++
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			op := token.text
+			if op == "<>" {
+				op = "!="
+			}
+			return &sqlBinary{op: op, left: left, right: right}, nil
+		}
+	}
+	if p.acceptKeyword("IS") {
+		not := p.acceptKeyword("NOT")
+		if err := p.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+		return &sqlIsNull{expr: left, not: not}, nil
+	}
+	not := p.acceptKeyword("NOT")
+	if p.acceptKeyword("LIKE") {
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		var expr sqlExpr = &sqlBinary{op: "LIKE", left: left, right: right}
+		if not {
+			expr = &sqlUnary{op: "NOT", expr: expr}
+		}
+		return expr, nil
+	}
+	if p.acceptKeyword("IN") {
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		in := &sqlIn{expr: left, not: not}
+		for {
+			value, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			in.list = append(in.list, value)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		return in, nil
+	}
+	if not {
+		return nil, p.errorf("expected LIKE or IN after NOT")
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseAdditive() (sqlExpr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isSymbol("+") || p.isSymbol("-") {
+		op := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseMultiplicative() (sqlExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isSymbol("*") || p.isSymbol("/") {
+		op := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &sqlBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseUnary() (sqlExpr, error) {
+	if p.acceptSymbol("-") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &sqlUnary{op: "-", expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *sqlParser) parsePrimary() (sqlExpr, error) {
+	token := p.peek()
+	switch token.kind {
+	case sqlTokenNumber:
+		p.pos++
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("sql: invalid number %q at %d", token.text, token.pos)
+		}
+		return &sqlLiteral{value: value}, nil
+	case sqlTokenString:
+		p.pos++
+		return &sqlLiteral{value: token.text}, nil
+	case sqlTokenPlaceholder:
+		p.pos++
+		return &sqlPlaceholder{index: token.index}, nil
+	case sqlTokenKeyword:
+		switch token.text {
+		case "NULL":
+			p.pos++
+			return &sqlLiteral{}, nil
+		case "TRUE", "FALSE":
+			p.pos++
+			return &sqlLiteral{value: token.text == "TRUE"}, nil
+		}
+	case sqlTokenSymbol:
+		if token.text == "(" {
+			p.pos++
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return expr, p.expectSymbol(")")
+		}
+	case sqlTokenIdent:
+		p.pos++
+		if p.acceptSymbol("(") {
+			return p.parseCall(token.text)
+		}
+		if p.acceptSymbol(".") {
+			name, err := p.parseIdent()
+			if err != nil {
+				return nil, err
+			}
+			return &sqlColumnRef{table: token.text, name: name}, nil
+		}
+		return &sqlColumnRef{name: token.text}, nil
+	}
+	return nil, p.errorf("unexpected %q", token.text)
+}
+
+func (p *sqlParser) parseCall(name string) (sqlExpr, error) {
+	call := &sqlCall{name: strings.ToUpper(name)}
+	if p.acceptSymbol("*") {
+		call.star = true
+		return call, p.expectSymbol(")")
+	}
+	call.distinct = p.acceptKeyword("DISTINCT")
+	if p.acceptSymbol(")") {
+		return call, nil
+	}
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	return call, p.expectSymbol(")")
+}
@@ -0,0 +1,263 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"strings"
+	"testing"
+)
+
+// 以完全加括号的形式输出 SELECT 语句，用于比较解析结果
+func formatSQLSelect(stmt *sqlSelect) string {
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, item := range stmt.items {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		switch {
+		case item.star && item.starTable != "":
+			sb.WriteString("`" + item.starTable + "`.*")
+		case item.star:
+			sb.WriteString("*")
+		default:
+			sb.WriteString(formatSQLExpr(item.expr))
+		}
+		if item.alias != "" {
+			sb.WriteString(" AS `" + item.alias + "`")
+		}
+	}
+	sb.WriteString(" FROM " + formatSQLTableRef(stmt.from))
+	for _, join := range stmt.joins {
+		if join.left {
+			sb.WriteString(" LEFT")
+		}
+		sb.WriteString(" JOIN " + formatSQLTableRef(join.table) + " ON " + formatSQLExpr(join.on))
+	}
+	if stmt.where != nil {
+		sb.WriteString(" WHERE " + formatSQLExpr(stmt.where))
+	}
+	for i, expr := range stmt.groupBy {
+		if i == 0 {
+			sb.WriteString(" GROUP BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(formatSQLExpr(expr))
+	}
+	if stmt.having != nil {
+		sb.WriteString(" HAVING " + formatSQLExpr(stmt.having))
+	}
+	for i, order := range stmt.orderBy {
+		if i == 0 {
+			sb.WriteString(" ORDER BY ")
+		} else {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(formatSQLExpr(order.expr))
+		if order.desc {
+			sb.WriteString(" DESC")
+		}
+	}
+	if stmt.limit != nil {
+		sb.WriteString(" LIMIT " + formatSQLExpr(stmt.limit))
+	}
+	if stmt.offset != nil {
+		sb.WriteString(" OFFSET " + formatSQLExpr(stmt.offset))
+	}
+	return sb.String()
+}
+
+func formatSQLTableRef(ref sqlTableRef) string {
+	if ref.alias != "" {
+		return "`" + ref.name + "` AS `" + ref.alias + "`"
+	}
+	return "`" + ref.name + "`"
+}
+
+func formatSQLExpr(expr sqlExpr) string {
+	switch e := expr.(type) {
+	case *sqlColumnRef:
+		if e.table != "" {
+			return "`" + e.table + "`.`" + e.name + "`"
+		}
+		return "`" + e.name + "`"
+	case *sqlBinary:
+		return "(" + formatSQLExpr(e.left) + " " + e.op + " " + formatSQLExpr(e.right) + ")"
+	case *sqlUnary:
+		if e.op == "NOT" {
+			return "(NOT " + formatSQLExpr(e.expr) + ")"
+		}
+		return "(" + e.op + formatSQLExpr(e.expr) + ")"
+	case *sqlIsNull:
+		if e.not {
+			return "(" + formatSQLExpr(e.expr) + " IS NOT NULL)"
+		}
+		return "(" + formatSQLExpr(e.expr) + " IS NULL)"
+	case *sqlIn:
+		items := make([]string, 0, len(e.list))
+		for _, item := range e.list {
+			items = append(items, formatSQLExpr(item))
+		}
+		op := " IN ("
+		if e.not {
+			op = " NOT IN ("
+		}
+		return "(" + formatSQLExpr(e.expr) + op + strings.Join(items, ", ") + "))"
+	}
+	return sqlExprString(expr)
+}
+
+func TestParseSQL_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "star",
+			query: "select * from Orders",
+			want:  "SELECT * FROM `Orders`",
+		},
+		{
+			name:  "columns_and_aliases",
+			query: "SELECT o.Amount AS amt, `客户 名称` total, [Note] FROM Orders o;",
+			want:  "SELECT `o`.`Amount` AS `amt`, `客户 名称` AS `total`, `Note` FROM `Orders` AS `o`",
+		},
+		{
+			name:  "table_star",
+			query: "SELECT o.*, c.Name FROM Orders AS o JOIN Customers c ON o.Customer = c.record_id",
+			want:  "SELECT `o`.*, `c`.`Name` FROM `Orders` AS `o` JOIN `Customers` AS `c` ON (`o`.`Customer` = `c`.`record_id`)",
+		},
+		{
+			name:  "left_outer_join",
+			query: "SELECT a FROM t LEFT OUTER JOIN u ON t.x = u.y",
+			want:  "SELECT `a` FROM `t` LEFT JOIN `u` ON (`t`.`x` = `u`.`y`)",
+		},
+		{
+			name:  "precedence",
+			query: "SELECT a FROM t WHERE a = 1 OR b = 2 AND NOT c > 3 + 4 * 5",
+			want:  "SELECT `a` FROM `t` WHERE ((`a` = 1) OR ((`b` = 2) AND (NOT (`c` > (3 + (4 * 5))))))",
+		},
+		{
+			name:  "parentheses_and_unary_minus",
+			query: "SELECT -(a - 1) / 2 FROM t",
+			want:  "SELECT ((-(`a` - 1)) / 2) FROM `t`",
+		},
+		{
+			name:  "not_equal_variants",
+			query: "SELECT a FROM t WHERE a <> 'x' AND b != 'y'",
+			want:  "SELECT `a` FROM `t` WHERE ((`a` != 'x') AND (`b` != 'y'))",
+		},
+		{
+			name:  "predicates",
+			query: "SELECT a FROM t WHERE a IS NOT NULL AND b IS NULL AND c IN (1, 2) AND d NOT IN ('x') AND e LIKE 'a%' AND f NOT LIKE '%b'",
+			want:  "SELECT `a` FROM `t` WHERE ((((((`a` IS NOT NULL) AND (`b` IS NULL)) AND (`c` IN (1, 2))) AND (`d` NOT IN ('x'))) AND (`e` LIKE 'a%')) AND (NOT (`f` LIKE '%b')))",
+		},
+		{
+			name:  "literals_and_placeholders",
+			query: "SELECT a FROM t WHERE b = 'it''s' AND c = TRUE AND d = NULL AND e = ? AND f = 1.5 -- comment",
+			want:  "SELECT `a` FROM `t` WHERE (((((`b` = 'it''s') AND (`c` = true)) AND (`d` = NULL)) AND (`e` = ?)) AND (`f` = 1.5))",
+		},
+		{
+			name:  "aggregates_group_having",
+			query: "SELECT Region, COUNT(*), COUNT(DISTINCT Owner), sum(Amount) AS total FROM t GROUP BY Region HAVING SUM(Amount) > 10",
+			want:  "SELECT `Region`, COUNT(*), COUNT(DISTINCT Owner), SUM(Amount) AS `total` FROM `t` GROUP BY `Region` HAVING (SUM(Amount) > 10)",
+		},
+		{
+			name:  "order_limit_offset",
+			query: "SELECT a FROM t ORDER BY a DESC, b ASC, c LIMIT ? OFFSET 20",
+			want:  "SELECT `a` FROM `t` ORDER BY `a` DESC, `b`, `c` LIMIT ? OFFSET 20",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, err := parseSQL(tt.query)
+			if err != nil {
+				t.Fatalf("parseSQL(%q) error = %v", tt.query, err)
+			}
+			got := formatSQLSelect(stmt.(*sqlSelect))
+			if got != tt.want {
+				t.Fatalf("parseSQL(%q) = %s, want %s", tt.query, got, tt.want)
+			}
+			// 输出结果再次解析应得到相同的语句
+			again, err := parseSQL(got)
+			if err != nil {
+				t.Fatalf("parseSQL(%q) error = %v", got, err)
+			}
+			if formatted := formatSQLSelect(again.(*sqlSelect)); formatted != got {
+				t.Fatalf("round trip = %s, want %s", formatted, got)
+			}
+		})
+	}
+}
+
+func TestParseSQL_Placeholders(t *testing.T) {
+	stmt, err := parseSQL("SELECT a FROM t WHERE a = ? AND b IN (?, ?) LIMIT ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var indexes []int
+	var walk func(expr sqlExpr)
+	walk = func(expr sqlExpr) {
+		switch e := expr.(type) {
+		case *sqlPlaceholder:
+			indexes = append(indexes, e.index)
+		case *sqlBinary:
+			walk(e.left)
+			walk(e.right)
+		case *sqlIn:
+			walk(e.expr)
+			for _, item := range e.list {
+				walk(item)
+			}
+		}
+	}
+	selectStmt := stmt.(*sqlSelect)
+	walk(selectStmt.where)
+	walk(selectStmt.limit)
+	if len(indexes) != 4 {
+		t.Fatalf("placeholders = %v, want 4", indexes)
+	}
+	for i, index := range indexes {
+		if index != i {
+			t.Fatalf("placeholders = %v, want in order from 0", indexes)
+		}
+	}
+}
+
+func TestParseSQL_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "empty", query: ""},
+		{name: "not_a_statement", query: "SHOW TABLES"},
+		{name: "missing_from", query: "SELECT a"},
+		{name: "missing_table", query: "SELECT a FROM"},
+		{name: "dangling_where", query: "SELECT a FROM t WHERE"},
+		{name: "trailing_tokens", query: "SELECT a FROM t t2 t3"},
+		{name: "unterminated_string", query: "SELECT a FROM t WHERE b = 'x"},
+		{name: "unterminated_identifier", query: "SELECT `a FROM t"},
+		{name: "unexpected_character", query: "SELECT a FROM t WHERE b = #"},
+		{name: "unbalanced_parentheses", query: "SELECT a FROM t WHERE (b = 1"},
+		{name: "not_without_like_or_in", query: "SELECT a FROM t WHERE b NOT = 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseSQL(tt.query); err == nil {
+				t.Fatalf("parseSQL(%q) expected an error", tt.query)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+// sqldriver 将多维表格注册为 database/sql 驱动，以空白导入的方式按需启用：
+//
+//	import _ "github.com/larksuite/base-sdk-go/v3/service/base/v1/sqldriver"
+//
+//	db, err := sql.Open("bitable", "https://base-api.feishu.cn?personal_base_token=xxx&app_token=xxx")
+package sqldriver
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+	"github.com/larksuite/base-sdk-go/v3/service/base/v1"
+)
+
+// database/sql 驱动名
+const DriverName = "bitable"
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// Driver 以 database/sql 的方式读写多维表格，支持的 SQL 见 BaseService.SQLConn
+//
+// - DSN 格式：https://base-api.feishu.cn?personal_base_token=xxx&app_token=xxx，其中协议和域名即 BaseUrl
+type Driver struct{}
+
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	config, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	larkcore.NewLogger(config)
+	larkcore.NewSerialization(config)
+	larkcore.NewHttpClient(config)
+	return larkbase.NewService(config).SQLConn(), nil
+}
+
+func parseDSN(dsn string) (*larkcore.Config, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("bitable: dsn must start with the base url, e.g. https://base-api.feishu.cn?personal_base_token=xxx&app_token=xxx")
+	}
+	query := u.Query()
+	config := &larkcore.Config{
+		BaseUrl:           strings.TrimSuffix(u.Scheme+"://"+u.Host+u.Path, "/"),
+		PersonalBaseToken: query.Get("personal_base_token"),
+		AppToken:          query.Get("app_token"),
+	}
+	if config.PersonalBaseToken == "" || config.AppToken == "" {
+		return nil, fmt.Errorf("bitable: dsn requires personal_base_token and app_token")
+	}
+	return config, nil
+}
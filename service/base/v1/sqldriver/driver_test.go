@@ -0,0 +1,66 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package sqldriver
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		name    string
+		dsn     string
+		baseUrl string
+		wantErr bool
+	}{
+		{
+			name:    "base_url",
+			dsn:     "https://base-api.feishu.cn?personal_base_token=pt&app_token=app",
+			baseUrl: "https://base-api.feishu.cn",
+		},
+		{
+			name:    "base_url_with_path",
+			dsn:     "http://127.0.0.1:8080/proxy/?personal_base_token=pt&app_token=app",
+			baseUrl: "http://127.0.0.1:8080/proxy",
+		},
+		{name: "missing_scheme", dsn: "base-api.feishu.cn?personal_base_token=pt&app_token=app", wantErr: true},
+		{name: "missing_token", dsn: "https://base-api.feishu.cn?app_token=app", wantErr: true},
+		{name: "missing_app_token", dsn: "https://base-api.feishu.cn?personal_base_token=pt", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseDSN(tt.dsn)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseDSN(%q) expected an error", tt.dsn)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDSN(%q) error = %v", tt.dsn, err)
+			}
+			if config.BaseUrl != tt.baseUrl || config.PersonalBaseToken != "pt" || config.AppToken != "app" {
+				t.Errorf("parseDSN(%q) = %+v", tt.dsn, config)
+			}
+		})
+	}
+}
+
+func TestDriverRegistered(t *testing.T) {
+	db, err := sql.Open(DriverName, "https://base-api.feishu.cn?personal_base_token=pt&app_token=app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+}
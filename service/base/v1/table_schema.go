@@ -0,0 +1,205 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 获取数据表的全部字段
+//
+// - 自动翻页，按接口返回的顺序排列
+func (a *appTableField) ListAll(ctx context.Context, tableId string, options ...larkcore.RequestOptionFunc) ([]*AppTableField, error) {
+	return a.listAll(ctx, "", tableId, options...)
+}
+
+// appToken 为空时使用 Config 中的 AppToken
+func (a *appTableField) listAll(ctx context.Context, appToken string, tableId string, options ...larkcore.RequestOptionFunc) ([]*AppTableField, error) {
+	builder := NewListAppTableFieldReqBuilder().TableId(tableId)
+	if appToken != "" {
+		builder.AppToken(appToken)
+	}
+	iterator, err := a.ListByIterator(ctx, builder.Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	var fields []*AppTableField
+	for {
+		hasMore, field, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return fields, nil
+		}
+		fields = append(fields, field)
+	}
+}
+
+// 获取多维表格的全部数据表
+func (a *appTable) ListAll(ctx context.Context, options ...larkcore.RequestOptionFunc) ([]*AppTable, error) {
+	iterator, err := a.ListByIterator(ctx, NewListAppTableReqBuilder().Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	var tables []*AppTable
+	for {
+		hasMore, table, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return tables, nil
+		}
+		tables = append(tables, table)
+	}
+}
+
+// 获取数据表的全部视图
+func (a *appTableView) ListAll(ctx context.Context, tableId string, options ...larkcore.RequestOptionFunc) ([]*AppTableView, error) {
+	iterator, err := a.ListByIterator(ctx, NewListAppTableViewReqBuilder().
+		TableId(tableId).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	var views []*AppTableView
+	for {
+		hasMore, view, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return views, nil
+		}
+		views = append(views, view)
+	}
+}
+
+// 获取数据表的全部记录
+func (a *appTableRecord) ListAll(ctx context.Context, tableId string, options ...larkcore.RequestOptionFunc) ([]*AppTableRecord, error) {
+	iterator, err := a.ListByIterator(ctx, NewListAppTableRecordReqBuilder().
+		TableId(tableId).
+		PageSize(500).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	var records []*AppTableRecord
+	for {
+		hasMore, record, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return records, nil
+		}
+		records = append(records, record)
+	}
+}
+
+// tableSchemaStore 同一个 BaseService 内共享的字段定义，补全选项后校验器能立即看到新的选项
+//
+// - 按 appToken/tableId 区分，同一客户端可以写入多个多维表格
+type tableSchemaStore struct {
+	mu      sync.Mutex
+	entries map[string]*tableSchemaEntry
+}
+
+// tableSchemaEntry 单个数据表的字段定义，获取时只锁住该数据表，同一数据表的并发获取只发出一次请求
+type tableSchemaEntry struct {
+	mu     sync.Mutex
+	schema *tableSchema
+}
+
+func (s *tableSchemaStore) entry(key string) *tableSchemaEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		entry = &tableSchemaEntry{}
+		s.entries[key] = entry
+	}
+	return entry
+}
+
+func tableSchemaKey(appToken string, tableId string) string {
+	return appToken + "/" + tableId
+}
+
+func (b *BaseService) schemaStore() *tableSchemaStore {
+	b.schemasOnce.Do(func() {
+		b.schemas = &tableSchemaStore{entries: map[string]*tableSchemaEntry{}}
+	})
+	return b.schemas
+}
+
+// tableSchemaCache 按数据表缓存字段定义，供写入前的校验、补全使用，各使用方有自己的有效期和请求选项
+type tableSchemaCache struct {
+	fields  *appTableField
+	ttl     time.Duration
+	options []larkcore.RequestOptionFunc
+	store   *tableSchemaStore
+}
+
+type tableSchema struct {
+	fields   []*AppTableField
+	byName   map[string]*AppTableField
+	loadedAt time.Time
+}
+
+func newTableSchemaCache(b *BaseService, ttl time.Duration, options []larkcore.RequestOptionFunc) *tableSchemaCache {
+	return &tableSchemaCache{fields: b.AppTableField, ttl: ttl, options: options, store: b.schemaStore()}
+}
+
+// 获取数据表的字段定义，缓存超过 ttl 时重新获取
+func (c *tableSchemaCache) get(ctx context.Context, appToken string, tableId string) (*tableSchema, error) {
+	return c.load(ctx, appToken, tableId, c.ttl)
+}
+
+// 缓存的字段定义已超过 minAge 时重新获取，否则返回缓存
+func (c *tableSchemaCache) refresh(ctx context.Context, appToken string, tableId string, minAge time.Duration) (*tableSchema, error) {
+	return c.load(ctx, appToken, tableId, minAge)
+}
+
+func (c *tableSchemaCache) load(ctx context.Context, appToken string, tableId string, maxAge time.Duration) (*tableSchema, error) {
+	entry := c.store.entry(tableSchemaKey(appToken, tableId))
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.schema != nil && time.Since(entry.schema.loadedAt) < maxAge {
+		return entry.schema, nil
+	}
+	fields, err := c.fields.listAll(ctx, appToken, tableId, c.options...)
+	if err != nil {
+		return nil, err
+	}
+	schema := &tableSchema{fields: fields, byName: map[string]*AppTableField{}, loadedAt: time.Now()}
+	for _, field := range fields {
+		if field.FieldName != nil {
+			schema.byName[*field.FieldName] = field
+		}
+	}
+	entry.schema = schema
+	return schema, nil
+}
+
+func (c *tableSchemaCache) invalidate(appToken string, tableId string) {
+	entry := c.store.entry(tableSchemaKey(appToken, tableId))
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	entry.schema = nil
+}
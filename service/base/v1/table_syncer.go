@@ -0,0 +1,461 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	SyncEventCreated = "created" // 新增记录
+	SyncEventUpdated = "updated" // 记录被修改
+	SyncEventDeleted = "deleted" // 记录被删除
+)
+
+// 默认每隔多少轮增量同步做一次全量记录ID对账
+const defaultSyncReconcileEvery = 10
+
+// SyncEvent 本地镜像的一次变更
+type SyncEvent struct {
+	Type     string          // 变更类型，取值见 SyncEvent* 常量
+	RecordId string          // 记录ID
+	Before   *AppTableRecord // 变更前的记录，新增时为空
+	After    *AppTableRecord // 变更后的记录，删除时为空
+}
+
+// SyncState 数据表的同步水位
+type SyncState struct {
+	Watermark int64 `json:"watermark"` // 已同步记录中最大的 last_modified_time（毫秒）
+	Rounds    int   `json:"rounds"`    // 已完成的同步轮数
+}
+
+// SyncStore 同步状态与本地镜像的持久化存储
+//
+// - 水位与记录分开保存，每轮只写入发生变化的记录
+type SyncStore interface {
+	// 读取同步水位，从未保存过时返回 nil, nil
+	LoadState(ctx context.Context, tableId string) (*SyncState, error)
+	// 保存同步水位
+	SaveState(ctx context.Context, tableId string, state *SyncState) error
+	// 读取镜像中的记录，不存在时返回 nil, nil
+	GetRecord(ctx context.Context, tableId string, recordId string) (*AppTableRecord, error)
+	// 新增或覆盖镜像中的记录
+	PutRecord(ctx context.Context, tableId string, record *AppTableRecord) error
+	// 删除镜像中的记录
+	DeleteRecord(ctx context.Context, tableId string, recordId string) error
+	// 镜像中的全部记录ID
+	RecordIds(ctx context.Context, tableId string) ([]string, error)
+}
+
+// MemorySyncStore 将同步状态保存在内存中，进程退出后丢失
+type MemorySyncStore struct {
+	mu      sync.Mutex
+	states  map[string]SyncState
+	records map[string]map[string]*AppTableRecord
+}
+
+func NewMemorySyncStore() *MemorySyncStore {
+	return &MemorySyncStore{states: map[string]SyncState{}, records: map[string]map[string]*AppTableRecord{}}
+}
+
+func (m *MemorySyncStore) LoadState(ctx context.Context, tableId string) (*SyncState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.states[tableId]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (m *MemorySyncStore) SaveState(ctx context.Context, tableId string, state *SyncState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[tableId] = *state
+	return nil
+}
+
+func (m *MemorySyncStore) GetRecord(ctx context.Context, tableId string, recordId string) (*AppTableRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.records[tableId][recordId], nil
+}
+
+func (m *MemorySyncStore) PutRecord(ctx context.Context, tableId string, record *AppTableRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	records, ok := m.records[tableId]
+	if !ok {
+		records = map[string]*AppTableRecord{}
+		m.records[tableId] = records
+	}
+	records[*record.RecordId] = record
+	return nil
+}
+
+func (m *MemorySyncStore) DeleteRecord(ctx context.Context, tableId string, recordId string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records[tableId], recordId)
+	return nil
+}
+
+func (m *MemorySyncStore) RecordIds(ctx context.Context, tableId string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	recordIds := make([]string, 0, len(m.records[tableId]))
+	for recordId := range m.records[tableId] {
+		recordIds = append(recordIds, recordId)
+	}
+	return recordIds, nil
+}
+
+// FileSyncStore 将同步状态以 JSON 文件保存在目录下
+//
+// - 每张数据表一个子目录，state.json 保存水位，records 目录下每条记录一个文件
+type FileSyncStore struct {
+	dir string
+}
+
+func NewFileSyncStore(dir string) *FileSyncStore {
+	return &FileSyncStore{dir: dir}
+}
+
+func (f *FileSyncStore) LoadState(ctx context.Context, tableId string) (*SyncState, error) {
+	state := &SyncState{}
+	ok, err := f.read(filepath.Join(f.tableDir(tableId), "state.json"), state)
+	if !ok {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (f *FileSyncStore) SaveState(ctx context.Context, tableId string, state *SyncState) error {
+	return f.write(filepath.Join(f.tableDir(tableId), "state.json"), state)
+}
+
+func (f *FileSyncStore) GetRecord(ctx context.Context, tableId string, recordId string) (*AppTableRecord, error) {
+	record := &AppTableRecord{}
+	ok, err := f.read(f.recordPath(tableId, recordId), record)
+	if !ok {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (f *FileSyncStore) PutRecord(ctx context.Context, tableId string, record *AppTableRecord) error {
+	return f.write(f.recordPath(tableId, *record.RecordId), record)
+}
+
+func (f *FileSyncStore) DeleteRecord(ctx context.Context, tableId string, recordId string) error {
+	err := os.Remove(f.recordPath(tableId, recordId))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (f *FileSyncStore) RecordIds(ctx context.Context, tableId string) ([]string, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(f.tableDir(tableId), "records"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	recordIds := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if name := entry.Name(); strings.HasSuffix(name, ".json") {
+			recordIds = append(recordIds, strings.TrimSuffix(name, ".json"))
+		}
+	}
+	return recordIds, nil
+}
+
+func (f *FileSyncStore) tableDir(tableId string) string {
+	return filepath.Join(f.dir, sanitizeFileName(tableId))
+}
+
+func (f *FileSyncStore) recordPath(tableId string, recordId string) string {
+	return filepath.Join(f.tableDir(tableId), "records", sanitizeFileName(recordId)+".json")
+}
+
+// 读取 JSON 文件，文件不存在时返回 false, nil
+func (f *FileSyncStore) read(path string, v interface{}) (bool, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// 先写临时文件再替换，避免中途退出留下不完整的文件
+func (f *FileSyncStore) write(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Syncer 增量同步一张数据表到本地镜像
+//
+// - 每轮按“最后更新时间”字段过滤，只拉取修改时间不早于水位线的记录，对比镜像后回调新增、修改事件
+//
+// - 未指定“最后更新时间”字段时，首轮同步会在数据表中自动查找；数据表中没有该类型字段时，每轮拉取全部记录并对账，
+// 结果相同但请求量与记录数成正比，大表建议添加“最后更新时间”字段
+//
+// - 增量同步时，删除通过定期比对全量记录ID发现
+type Syncer struct {
+	service           *BaseService
+	tableId           string
+	store             SyncStore
+	onEvent           func(*SyncEvent)
+	options           []larkcore.RequestOptionFunc
+	modifiedTimeField string
+	resolved          bool // 是否已确定 modifiedTimeField，为空表示全量同步
+	reconcileEvery    int
+
+	mu sync.Mutex
+}
+
+// 创建同步器，onEvent 按变更发生的顺序被回调
+func (b *BaseService) NewSyncer(tableId string, store SyncStore, onEvent func(*SyncEvent), options ...larkcore.RequestOptionFunc) *Syncer {
+	return &Syncer{
+		service:        b,
+		tableId:        tableId,
+		store:          store,
+		onEvent:        onEvent,
+		options:        options,
+		reconcileEvery: defaultSyncReconcileEvery,
+	}
+}
+
+// 设置数据表中“最后更新时间”类型（TypeModifiedTime）字段的字段名，用于按修改时间过滤记录
+func (s *Syncer) ModifiedTimeField(fieldName string) *Syncer {
+	s.modifiedTimeField = fieldName
+	s.resolved = fieldName != ""
+	return s
+}
+
+// 设置每隔多少轮增量同步做一次全量记录ID对账，小于 1 表示每轮都对账
+func (s *Syncer) ReconcileEvery(rounds int) *Syncer {
+	s.reconcileEvery = rounds
+	return s
+}
+
+// 执行一轮同步
+//
+// - 每个事件回调完成后立即写入对应记录，全部完成后才保存新的水位线，失败时下一轮会从旧水位线重新拉取
+func (s *Syncer) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.store.LoadState(ctx, s.tableId)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &SyncState{}
+	}
+	if !s.resolved {
+		fields, err := s.service.AppTableField.ListAll(ctx, s.tableId, s.options...)
+		if err != nil {
+			return err
+		}
+		s.useFields(fields)
+	}
+	full := s.modifiedTimeField == ""
+
+	changed, err := s.pull(ctx, state.Watermark)
+	if err != nil {
+		return err
+	}
+
+	var events []*SyncEvent
+	watermark := state.Watermark
+	for _, record := range changed {
+		recordId := *record.RecordId
+		modifiedTime := recordModifiedTime(record)
+		if modifiedTime > watermark {
+			watermark = modifiedTime
+		}
+		before, err := s.store.GetRecord(ctx, s.tableId, recordId)
+		if err != nil {
+			return err
+		}
+		if before == nil {
+			events = append(events, &SyncEvent{Type: SyncEventCreated, RecordId: recordId, After: record})
+		} else if recordModifiedTime(before) != modifiedTime {
+			events = append(events, &SyncEvent{Type: SyncEventUpdated, RecordId: recordId, Before: before, After: record})
+		}
+	}
+
+	// 全量同步时拉取的记录即为当前全部记录，每轮对账；增量同步时首轮镜像为空，无需对账，之后按轮数定期对账
+	if full || (state.Rounds > 0 && (s.reconcileEvery < 1 || (state.Rounds+1)%s.reconcileEvery == 0)) {
+		existing := map[string]bool{}
+		if full {
+			for _, record := range changed {
+				existing[*record.RecordId] = true
+			}
+		} else if existing, err = s.recordIds(ctx); err != nil {
+			return err
+		}
+		recordIds, err := s.store.RecordIds(ctx, s.tableId)
+		if err != nil {
+			return err
+		}
+		for _, recordId := range recordIds {
+			if existing[recordId] {
+				continue
+			}
+			before, err := s.store.GetRecord(ctx, s.tableId, recordId)
+			if err != nil {
+				return err
+			}
+			events = append(events, &SyncEvent{Type: SyncEventDeleted, RecordId: recordId, Before: before})
+		}
+	}
+
+	for _, event := range events {
+		if s.onEvent != nil {
+			s.onEvent(event)
+		}
+		if event.Type == SyncEventDeleted {
+			err = s.store.DeleteRecord(ctx, s.tableId, event.RecordId)
+		} else {
+			err = s.store.PutRecord(ctx, s.tableId, event.After)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	state.Watermark = watermark
+	state.Rounds++
+	return s.store.SaveState(ctx, s.tableId, state)
+}
+
+// 每隔 interval 执行一次 Sync，直到 ctx 结束或同步失败
+func (s *Syncer) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.Sync(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// 使用数据表中第一个“最后更新时间”类型的字段，没有时改为全量同步
+func (s *Syncer) useFields(fields []*AppTableField) {
+	s.modifiedTimeField = ""
+	for _, field := range fields {
+		if field.Type != nil && *field.Type == TypeModifiedTime && field.FieldName != nil {
+			s.modifiedTimeField = *field.FieldName
+			break
+		}
+	}
+	s.resolved = true
+}
+
+// 拉取修改时间不早于水位线的记录，全量同步时拉取全部记录
+func (s *Syncer) pull(ctx context.Context, watermark int64) ([]*AppTableRecord, error) {
+	builder := NewListAppTableRecordReqBuilder().
+		TableId(s.tableId).
+		AutomaticFields(true)
+	if s.modifiedTimeField == "" {
+		watermark = 0
+	} else {
+		builder.Filter(fmt.Sprintf("CurrentValue.[%s]>=%d", s.modifiedTimeField, watermark))
+	}
+	iterator, err := s.service.AppTableRecord.ListByIterator(ctx, builder.Build(), s.options...)
+	if err != nil {
+		return nil, err
+	}
+	var records []*AppTableRecord
+	for {
+		hasMore, record, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return records, nil
+		}
+		if record.RecordId == nil || recordModifiedTime(record) < watermark {
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+// 获取数据表当前全部记录ID
+func (s *Syncer) recordIds(ctx context.Context) (map[string]bool, error) {
+	iterator, err := s.service.AppTableRecord.ListByIterator(ctx, NewListAppTableRecordReqBuilder().
+		TableId(s.tableId).
+		FieldNames("[]").
+		Build(), s.options...)
+	if err != nil {
+		return nil, err
+	}
+	recordIds := map[string]bool{}
+	for {
+		hasMore, record, err := iterator.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !hasMore {
+			return recordIds, nil
+		}
+		if record.RecordId != nil {
+			recordIds[*record.RecordId] = true
+		}
+	}
+}
+
+func recordModifiedTime(record *AppTableRecord) int64 {
+	if record.LastModifiedTime != nil {
+		return *record.LastModifiedTime
+	}
+	if record.CreatedTime != nil {
+		return *record.CreatedTime
+	}
+	return 0
+}
@@ -0,0 +1,426 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	ViewFilterOperatorIs             = "is"             // 等于
+	ViewFilterOperatorIsNot          = "isNot"          // 不等于
+	ViewFilterOperatorContains       = "contains"       // 包含
+	ViewFilterOperatorDoesNotContain = "doesNotContain" // 不包含
+	ViewFilterOperatorIsEmpty        = "isEmpty"        // 为空
+	ViewFilterOperatorIsNotEmpty     = "isNotEmpty"     // 不为空
+	ViewFilterOperatorIsGreater      = "isGreater"      // 大于
+	ViewFilterOperatorIsGreaterEqual = "isGreaterEqual" // 大于等于
+	ViewFilterOperatorIsLess         = "isLess"         // 小于
+	ViewFilterOperatorIsLessEqual    = "isLessEqual"    // 小于等于
+)
+
+const (
+	ViewFilterConjunctionAnd = "and" // 满足全部条件
+	ViewFilterConjunctionOr  = "or"  // 满足任一条件
+)
+
+// 日期筛选值，精确日期直接传 time.Time
+const (
+	ViewDateToday        = "Today"        // 今天
+	ViewDateTomorrow     = "Tomorrow"     // 明天
+	ViewDateYesterday    = "Yesterday"    // 昨天
+	ViewDateCurrentWeek  = "CurrentWeek"  // 本周
+	ViewDateLastWeek     = "LastWeek"     // 上周
+	ViewDateCurrentMonth = "CurrentMonth" // 本月
+	ViewDateLastMonth    = "LastMonth"    // 上月
+	ViewDateTheLastWeek  = "TheLastWeek"  // 过去 7 天
+	ViewDateTheNextWeek  = "TheNextWeek"  // 未来 7 天
+	ViewDateTheLastMonth = "TheLastMonth" // 过去 30 天
+	ViewDateTheNextMonth = "TheNextMonth" // 未来 30 天
+	viewDateExactDate    = "ExactDate"
+)
+
+var viewDates = map[string]bool{
+	ViewDateToday: true, ViewDateTomorrow: true, ViewDateYesterday: true,
+	ViewDateCurrentWeek: true, ViewDateLastWeek: true, ViewDateCurrentMonth: true, ViewDateLastMonth: true,
+	ViewDateTheLastWeek: true, ViewDateTheNextWeek: true, ViewDateTheLastMonth: true, ViewDateTheNextMonth: true,
+}
+
+var (
+	viewEmptyOperators   = []string{ViewFilterOperatorIsEmpty, ViewFilterOperatorIsNotEmpty}
+	viewTextOperators    = append([]string{ViewFilterOperatorIs, ViewFilterOperatorIsNot, ViewFilterOperatorContains, ViewFilterOperatorDoesNotContain}, viewEmptyOperators...)
+	viewCompareOperators = append([]string{ViewFilterOperatorIs, ViewFilterOperatorIsNot, ViewFilterOperatorIsGreater, ViewFilterOperatorIsGreaterEqual, ViewFilterOperatorIsLess, ViewFilterOperatorIsLessEqual}, viewEmptyOperators...)
+	viewDateOperators    = append([]string{ViewFilterOperatorIs, ViewFilterOperatorIsGreater, ViewFilterOperatorIsLess}, viewEmptyOperators...)
+	viewListOperators    = append([]string{ViewFilterOperatorIs, ViewFilterOperatorIsNot, ViewFilterOperatorContains, ViewFilterOperatorDoesNotContain}, viewEmptyOperators...)
+)
+
+// 视图筛选条件中各字段类型支持的运算符
+var viewFilterOperators = map[int][]string{
+	TypeText:         viewTextOperators,
+	TypeUrl:          viewTextOperators,
+	TypePhoneNumber:  viewTextOperators,
+	TypeLocation:     viewTextOperators,
+	TypeAutoSerial:   viewTextOperators,
+	TypeFormula:      viewTextOperators,
+	TypeNumber:       viewCompareOperators,
+	TypeSingleSelect: viewListOperators,
+	TypeMultiSelect:  viewListOperators,
+	TypeDateTime:     viewDateOperators,
+	TypeCreatedTime:  viewDateOperators,
+	TypeModifiedTime: viewDateOperators,
+	TypeCheckbox:     {ViewFilterOperatorIs},
+	TypeUser:         viewListOperators,
+	TypeCreatedUser:  viewListOperators,
+	TypeModifiedUser: viewListOperators,
+	TypeGroupChat:    viewListOperators,
+	TypeLink:         viewListOperators,
+	TypeDuplexLink:   viewListOperators,
+	TypeAttachment:   viewEmptyOperators,
+}
+
+// ViewBuilder 按字段名构造视图的筛选条件、隐藏字段和层级结构，生成更新视图的请求
+//
+// - 根据字段类型校验运算符，并将筛选值编码为接口需要的 JSON 字符串：单选、多选字段传选项名，
+// 人员字段传用户 ID，关联字段传记录 ID，日期字段传 ViewDate* 常量或 time.Time
+//
+// - 所有校验错误在 Build 时合并返回，不会发出请求
+type ViewBuilder struct {
+	tableId     string
+	viewId      string
+	fields      map[string]*AppTableField
+	viewName    *string
+	conditions  []*AppTableViewPropertyFilterInfoCondition
+	conjunction string
+	hidden      []string
+	hiddenSet   bool
+	hierarchy   *string
+	hasRange    bool
+	errs        []error
+}
+
+// 获取数据表字段并创建视图构造器
+func (b *BaseService) NewViewBuilder(ctx context.Context, tableId string, viewId string, options ...larkcore.RequestOptionFunc) (*ViewBuilder, error) {
+	fields, err := b.AppTableField.ListAll(ctx, tableId, options...)
+	if err != nil {
+		return nil, err
+	}
+	return NewViewBuilder(tableId, viewId, fields), nil
+}
+
+// 使用已获取的字段定义创建视图构造器
+func NewViewBuilder(tableId string, viewId string, fields []*AppTableField) *ViewBuilder {
+	builder := &ViewBuilder{tableId: tableId, viewId: viewId, fields: map[string]*AppTableField{}, conjunction: ViewFilterConjunctionAnd}
+	for _, field := range fields {
+		if field.FieldName != nil {
+			builder.fields[*field.FieldName] = field
+		}
+	}
+	return builder
+}
+
+// 视图名称
+func (v *ViewBuilder) ViewName(name string) *ViewBuilder {
+	v.viewName = &name
+	return v
+}
+
+// 添加一个筛选条件，为空、不为空条件不需要筛选值
+func (v *ViewBuilder) Where(fieldName string, operator string, values ...interface{}) *ViewBuilder {
+	condition, err := v.condition(fieldName, operator, values)
+	if err != nil {
+		v.errs = append(v.errs, err)
+		return v
+	}
+	v.conditions = append(v.conditions, condition)
+	return v
+}
+
+// 添加日期范围筛选条件，筛选 [from, to] 之间的记录，零值表示不限制
+//
+// - 范围条件依赖 and 关系，与 or 关系同时使用时 Build 返回错误
+func (v *ViewBuilder) WhereDateBetween(fieldName string, from time.Time, to time.Time) *ViewBuilder {
+	if from.IsZero() && to.IsZero() {
+		v.errs = append(v.errs, fmt.Errorf("date range on field %s has no bounds", fieldName))
+		return v
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		v.errs = append(v.errs, fmt.Errorf("date range on field %s ends before it starts", fieldName))
+		return v
+	}
+	// 日期字段只支持大于、小于，按天比较，因此向外扩展一天
+	if !from.IsZero() {
+		v.Where(fieldName, ViewFilterOperatorIsGreater, from.AddDate(0, 0, -1))
+	}
+	if !to.IsZero() {
+		v.Where(fieldName, ViewFilterOperatorIsLess, to.AddDate(0, 0, 1))
+	}
+	v.hasRange = true
+	return v
+}
+
+// 多个筛选条件之间的关系，取值见 ViewFilterConjunction* 常量，默认为 and
+func (v *ViewBuilder) Conjunction(conjunction string) *ViewBuilder {
+	if conjunction != ViewFilterConjunctionAnd && conjunction != ViewFilterConjunctionOr {
+		v.errs = append(v.errs, fmt.Errorf("invalid conjunction %q, must be %q or %q", conjunction, ViewFilterConjunctionAnd, ViewFilterConjunctionOr))
+		return v
+	}
+	v.conjunction = conjunction
+	return v
+}
+
+// 按字段名设置隐藏字段，不传字段名表示取消全部隐藏
+func (v *ViewBuilder) HiddenFields(fieldNames ...string) *ViewBuilder {
+	v.hidden = []string{}
+	v.hiddenSet = true
+	for _, name := range fieldNames {
+		field, ok := v.fields[name]
+		if !ok || field.FieldId == nil {
+			v.errs = append(v.errs, fmt.Errorf("hidden field %s not found", name))
+			continue
+		}
+		if field.IsPrimary != nil && *field.IsPrimary {
+			v.errs = append(v.errs, fmt.Errorf("primary field %s cannot be hidden", name))
+			continue
+		}
+		v.hidden = append(v.hidden, *field.FieldId)
+	}
+	return v
+}
+
+// 按关联到本表的单向关联字段设置层级结构
+func (v *ViewBuilder) Hierarchy(fieldName string) *ViewBuilder {
+	field, ok := v.fields[fieldName]
+	if !ok || field.FieldId == nil || field.Type == nil {
+		v.errs = append(v.errs, fmt.Errorf("hierarchy field %s not found", fieldName))
+		return v
+	}
+	if *field.Type != TypeLink || field.Property == nil || field.Property.TableId == nil || *field.Property.TableId != v.tableId {
+		v.errs = append(v.errs, fmt.Errorf("hierarchy field %s must be a one-way link to table %s", fieldName, v.tableId))
+		return v
+	}
+	v.hierarchy = field.FieldId
+	return v
+}
+
+// 校验并生成更新视图的请求，所有校验错误会合并返回
+func (v *ViewBuilder) Build() (*PatchAppTableViewReq, error) {
+	errs := append([]error{}, v.errs...)
+	if v.hasRange && v.conjunction == ViewFilterConjunctionOr {
+		errs = append(errs, errors.New("date ranges require the and conjunction"))
+	}
+	if len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, err := range errs {
+			messages = append(messages, err.Error())
+		}
+		return nil, errors.New(strings.Join(messages, "; "))
+	}
+
+	property := NewAppTableViewPropertyBuilder()
+	empty := true
+	if len(v.conditions) > 0 {
+		property.FilterInfo(NewAppTableViewPropertyFilterInfoBuilder().
+			Conjunction(v.conjunction).
+			Conditions(v.conditions).
+			Build())
+		empty = false
+	}
+	if v.hiddenSet {
+		property.HiddenFields(v.hidden)
+		empty = false
+	}
+	if v.hierarchy != nil {
+		property.HierarchyConfig(NewAppTableViewPropertyHierarchyConfigBuilder().FieldId(*v.hierarchy).Build())
+		empty = false
+	}
+	body := NewPatchAppTableViewReqBodyBuilder()
+	if v.viewName != nil {
+		body.ViewName(*v.viewName)
+	} else if empty {
+		return nil, errors.New("view builder has nothing to update")
+	}
+	if !empty {
+		body.Property(property.Build())
+	}
+	req := NewPatchAppTableViewReqBuilder().
+		TableId(v.tableId).
+		ViewId(v.viewId).
+		Body(body.Build()).
+		Build()
+	if v.hiddenSet && len(v.hidden) == 0 {
+		// 生成的模型会省略空的 hidden_fields，取消全部隐藏时需要显式提交空数组
+		data, err := json.Marshal(req.apiReq.Body)
+		if err != nil {
+			return nil, err
+		}
+		raw := map[string]interface{}{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		property, _ := raw["property"].(map[string]interface{})
+		if property == nil {
+			property = map[string]interface{}{}
+			raw["property"] = property
+		}
+		property["hidden_fields"] = []string{}
+		req.apiReq.Body = raw
+	}
+	return req, nil
+}
+
+func (v *ViewBuilder) condition(fieldName string, operator string, values []interface{}) (*AppTableViewPropertyFilterInfoCondition, error) {
+	field, ok := v.fields[fieldName]
+	if !ok || field.Type == nil || field.FieldId == nil {
+		return nil, fmt.Errorf("field %s not found", fieldName)
+	}
+	operators, ok := viewFilterOperators[*field.Type]
+	if !ok {
+		return nil, fmt.Errorf("field %s of type %d cannot be used in view filters", fieldName, *field.Type)
+	}
+	valid := false
+	for _, candidate := range operators {
+		valid = valid || candidate == operator
+	}
+	if !valid {
+		return nil, fmt.Errorf("operator %q is not valid for field %s, expected one of %s", operator, fieldName, strings.Join(operators, ", "))
+	}
+
+	builder := NewAppTableViewPropertyFilterInfoConditionBuilder().
+		FieldId(*field.FieldId).
+		Operator(operator).
+		FieldType(strconv.Itoa(*field.Type))
+	if operator == ViewFilterOperatorIsEmpty || operator == ViewFilterOperatorIsNotEmpty {
+		if len(values) > 0 {
+			return nil, fmt.Errorf("operator %q on field %s takes no values", operator, fieldName)
+		}
+		return builder.Build(), nil
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("operator %q on field %s requires a value", operator, fieldName)
+	}
+	value, err := encodeViewFilterValue(field, operator, values)
+	if err != nil {
+		return nil, err
+	}
+	return builder.Value(value).Build(), nil
+}
+
+// 按字段类型将筛选值编码为 JSON 字符串
+func encodeViewFilterValue(field *AppTableField, operator string, values []interface{}) (string, error) {
+	fieldName := stringValue(field.FieldName)
+	single := func() error {
+		if len(values) != 1 {
+			return fmt.Errorf("operator %q on field %s takes exactly one value", operator, fieldName)
+		}
+		return nil
+	}
+
+	var encoded interface{}
+	switch *field.Type {
+	case TypeNumber:
+		if err := single(); err != nil {
+			return "", err
+		}
+		number, ok := sqlNumber(values[0])
+		if !ok {
+			return "", fmt.Errorf("value %v for number field %s is not a number", values[0], fieldName)
+		}
+		encoded = number
+	case TypeCheckbox:
+		if err := single(); err != nil {
+			return "", err
+		}
+		checked, ok := values[0].(bool)
+		if !ok {
+			return "", fmt.Errorf("value %v for checkbox field %s is not a bool", values[0], fieldName)
+		}
+		encoded = checked
+	case TypeDateTime, TypeCreatedTime, TypeModifiedTime:
+		if err := single(); err != nil {
+			return "", err
+		}
+		switch date := values[0].(type) {
+		case time.Time:
+			encoded = []string{viewDateExactDate, strconv.FormatInt(date.UnixMilli(), 10)}
+		case string:
+			if !viewDates[date] {
+				return "", fmt.Errorf("unknown date value %q for field %s", date, fieldName)
+			}
+			encoded = []string{date}
+		default:
+			return "", fmt.Errorf("value %v for date field %s must be a time.Time or a ViewDate* constant", values[0], fieldName)
+		}
+	case TypeSingleSelect, TypeMultiSelect:
+		if *field.Type == TypeSingleSelect && (operator == ViewFilterOperatorIs || operator == ViewFilterOperatorIsNot) {
+			if err := single(); err != nil {
+				return "", err
+			}
+		}
+		names, err := viewFilterStrings(fieldName, values)
+		if err != nil {
+			return "", err
+		}
+		ids := make([]string, 0, len(names))
+		for _, name := range names {
+			id, err := fieldOptionId(field, name)
+			if err != nil {
+				return "", err
+			}
+			ids = append(ids, id)
+		}
+		encoded = ids
+	case TypeUser, TypeCreatedUser, TypeModifiedUser, TypeGroupChat, TypeLink, TypeDuplexLink:
+		ids, err := viewFilterStrings(fieldName, values)
+		if err != nil {
+			return "", err
+		}
+		encoded = ids
+	default:
+		if err := single(); err != nil {
+			return "", err
+		}
+		texts, err := viewFilterStrings(fieldName, values)
+		if err != nil {
+			return "", err
+		}
+		encoded = texts
+	}
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func viewFilterStrings(fieldName string, values []interface{}) ([]string, error) {
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		switch value := value.(type) {
+		case string:
+			texts = append(texts, value)
+		case []string:
+			texts = append(texts, value...)
+		default:
+			return nil, fmt.Errorf("value %v for field %s must be a string", value, fieldName)
+		}
+	}
+	return texts, nil
+}
@@ -0,0 +1,208 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkbase
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestViewFields() []*AppTableField {
+	options := []*AppTableFieldPropertyOption{
+		NewAppTableFieldPropertyOptionBuilder().Id("opt_open").Name("Open").Build(),
+		NewAppTableFieldPropertyOptionBuilder().Id("opt_done").Name("Done").Build(),
+	}
+	return []*AppTableField{
+		NewAppTableFieldBuilder().FieldId("fldTitle").FieldName("Title").Type(TypeText).IsPrimary(true).Build(),
+		NewAppTableFieldBuilder().FieldId("fldAmount").FieldName("Amount").Type(TypeNumber).Build(),
+		NewAppTableFieldBuilder().FieldId("fldStatus").FieldName("Status").Type(TypeSingleSelect).Property(NewAppTableFieldPropertyBuilder().Options(options).Build()).Build(),
+		NewAppTableFieldBuilder().FieldId("fldDue").FieldName("Due").Type(TypeDateTime).Build(),
+		NewAppTableFieldBuilder().FieldId("fldPaid").FieldName("Paid").Type(TypeCheckbox).Build(),
+		NewAppTableFieldBuilder().FieldId("fldFiles").FieldName("Files").Type(TypeAttachment).Build(),
+		NewAppTableFieldBuilder().FieldId("fldParent").FieldName("Parent").Type(TypeLink).Property(NewAppTableFieldPropertyBuilder().TableId("tbl1").Build()).Build(),
+		NewAppTableFieldBuilder().FieldId("fldCustomer").FieldName("Customer").Type(TypeLink).Property(NewAppTableFieldPropertyBuilder().TableId("tbl2").Build()).Build(),
+		NewAppTableFieldBuilder().FieldId("fldPeer").FieldName("Peer").Type(TypeDuplexLink).Property(NewAppTableFieldPropertyBuilder().TableId("tbl1").Build()).Build(),
+	}
+}
+
+func TestViewBuilder_Build(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name    string
+		build   func(*ViewBuilder) *ViewBuilder
+		want    string
+		wantErr string
+	}{
+		{
+			name: "filters",
+			build: func(v *ViewBuilder) *ViewBuilder {
+				return v.Where("Amount", ViewFilterOperatorIsGreater, 100).
+					Where("Status", ViewFilterOperatorIs, "Done").
+					Where("Title", ViewFilterOperatorContains, "urgent").
+					Where("Paid", ViewFilterOperatorIs, true).
+					Where("Due", ViewFilterOperatorIs, ViewDateToday).
+					Where("Files", ViewFilterOperatorIsNotEmpty).
+					Conjunction(ViewFilterConjunctionOr)
+			},
+			want: `{"property":{"filter_info":{"conjunction":"or","conditions":[` +
+				`{"field_id":"fldAmount","operator":"isGreater","value":"100","field_type":"2"},` +
+				`{"field_id":"fldStatus","operator":"is","value":"[\"opt_done\"]","field_type":"3"},` +
+				`{"field_id":"fldTitle","operator":"contains","value":"[\"urgent\"]","field_type":"1"},` +
+				`{"field_id":"fldPaid","operator":"is","value":"true","field_type":"7"},` +
+				`{"field_id":"fldDue","operator":"is","value":"[\"Today\"]","field_type":"5"},` +
+				`{"field_id":"fldFiles","operator":"isNotEmpty","field_type":"17"}]}}}`,
+		},
+		{
+			name: "date_between_widened_by_a_day",
+			build: func(v *ViewBuilder) *ViewBuilder {
+				return v.WhereDateBetween("Due", day(10), day(20))
+			},
+			want: `{"property":{"filter_info":{"conjunction":"and","conditions":[` +
+				`{"field_id":"fldDue","operator":"isGreater","value":"[\"ExactDate\",\"1709942400000\"]","field_type":"5"},` +
+				`{"field_id":"fldDue","operator":"isLess","value":"[\"ExactDate\",\"1710979200000\"]","field_type":"5"}]}}}`,
+		},
+		{
+			name: "date_between_open_end",
+			build: func(v *ViewBuilder) *ViewBuilder {
+				return v.WhereDateBetween("Due", day(10), time.Time{})
+			},
+			want: `{"property":{"filter_info":{"conjunction":"and","conditions":[` +
+				`{"field_id":"fldDue","operator":"isGreater","value":"[\"ExactDate\",\"1709942400000\"]","field_type":"5"}]}}}`,
+		},
+		{
+			name: "hidden_and_hierarchy",
+			build: func(v *ViewBuilder) *ViewBuilder {
+				return v.ViewName("Tree").HiddenFields("Amount", "Paid").Hierarchy("Parent")
+			},
+			want: `{"view_name":"Tree","property":{"hidden_fields":["fldAmount","fldPaid"],"hierarchy_config":{"field_id":"fldParent"}}}`,
+		},
+		{
+			name:  "unhide_all",
+			build: func(v *ViewBuilder) *ViewBuilder { return v.HiddenFields() },
+			want:  `{"property":{"hidden_fields":[]}}`,
+		},
+		{
+			name:  "rename_only",
+			build: func(v *ViewBuilder) *ViewBuilder { return v.ViewName("Renamed") },
+			want:  `{"view_name":"Renamed"}`,
+		},
+		{
+			name:    "nothing_to_update",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v },
+			wantErr: "view builder has nothing to update",
+		},
+		{
+			name:    "operator_not_valid_for_date",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Where("Due", ViewFilterOperatorIsGreaterEqual, day(1)) },
+			wantErr: `operator "isGreaterEqual" is not valid for field Due`,
+		},
+		{
+			name:    "operator_not_valid_for_checkbox",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Where("Paid", ViewFilterOperatorIsNot, true) },
+			wantErr: `operator "isNot" is not valid for field Paid`,
+		},
+		{
+			name:    "operator_not_valid_for_attachment",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Where("Files", ViewFilterOperatorContains, "a.txt") },
+			wantErr: `operator "contains" is not valid for field Files`,
+		},
+		{
+			name: "wrong_value_types",
+			build: func(v *ViewBuilder) *ViewBuilder {
+				return v.Where("Amount", ViewFilterOperatorIs, "x").Where("Paid", ViewFilterOperatorIs, 1)
+			},
+			wantErr: "value x for number field Amount is not a number; value 1 for checkbox field Paid is not a bool",
+		},
+		{
+			name:    "unknown_date_value",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Where("Due", ViewFilterOperatorIs, "NextYear") },
+			wantErr: `unknown date value "NextYear" for field Due`,
+		},
+		{
+			name:    "unknown_option",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Where("Status", ViewFilterOperatorIs, "Blocked") },
+			wantErr: `option "Blocked" not found in field Status`,
+		},
+		{
+			name:    "empty_operator_with_value",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Where("Title", ViewFilterOperatorIsEmpty, "x") },
+			wantErr: "takes no values",
+		},
+		{
+			name: "date_range_with_or",
+			build: func(v *ViewBuilder) *ViewBuilder {
+				return v.WhereDateBetween("Due", day(10), day(20)).Conjunction(ViewFilterConjunctionOr)
+			},
+			wantErr: "date ranges require the and conjunction",
+		},
+		{
+			name:    "date_range_reversed",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.WhereDateBetween("Due", day(20), day(10)) },
+			wantErr: "date range on field Due ends before it starts",
+		},
+		{
+			name:    "hide_primary",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.HiddenFields("Title") },
+			wantErr: "primary field Title cannot be hidden",
+		},
+		{
+			name:    "hierarchy_link_to_other_table",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Hierarchy("Customer") },
+			wantErr: "hierarchy field Customer must be a one-way link to table tbl1",
+		},
+		{
+			name:    "hierarchy_duplex_link",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Hierarchy("Peer") },
+			wantErr: "hierarchy field Peer must be a one-way link to table tbl1",
+		},
+		{
+			name:    "hierarchy_not_found",
+			build:   func(v *ViewBuilder) *ViewBuilder { return v.Hierarchy("Missing") },
+			wantErr: "hierarchy field Missing not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := tt.build(NewViewBuilder("tbl1", "vew1", newTestViewFields())).Build()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Build() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := json.Marshal(req.apiReq.Body)
+			if string(body) != tt.want {
+				t.Errorf("Build() body = %s, want %s", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewBuilder_BuildRepeated(t *testing.T) {
+	builder := NewViewBuilder("tbl1", "vew1", newTestViewFields()).
+		WhereDateBetween("Due", time.Now(), time.Time{}).
+		Conjunction(ViewFilterConjunctionOr)
+	_, first := builder.Build()
+	_, second := builder.Build()
+	if first == nil || second == nil || first.Error() != second.Error() {
+		t.Errorf("Build() errors differ between calls: %v / %v", first, second)
+	}
+	if _, err := builder.Conjunction(ViewFilterConjunctionAnd).Build(); err != nil {
+		t.Errorf("Build() after fixing the conjunction = %v, want nil", err)
+	}
+}
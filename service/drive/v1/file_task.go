@@ -0,0 +1,117 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	TaskStatusSuccess = "success" // 任务执行成功
+	TaskStatusFail    = "fail"    // 任务执行失败
+	TaskStatusProcess = "process" // 任务执行中
+)
+
+// 异步任务轮询间隔
+const fileTaskPollInterval = time.Second
+
+// 查询结果中连续缺少任务状态的最大次数，超过后放弃等待
+const fileTaskMaxEmptyPolls = 10
+
+// 等待移动、删除文件夹等异步任务结束
+//
+// - taskId 为空时直接返回，便于处理移动、删除普通文件时不返回 task_id 的情况
+//
+// - 每隔一秒查询一次任务状态，直到任务成功、失败或 ctx 结束；返回未知状态或连续多次未返回状态时返回错误
+func (f *file) WaitTask(ctx context.Context, taskId string, options ...larkcore.RequestOptionFunc) error {
+	if taskId == "" {
+		return nil
+	}
+	req := NewTaskCheckFileReqBuilder().TaskId(taskId).Build()
+	emptyPolls := 0
+	for {
+		resp, err := f.TaskCheck(ctx, req, options...)
+		if err != nil {
+			return err
+		}
+		if !resp.Success() {
+			return resp.CodeError
+		}
+		if resp.Data != nil && resp.Data.Status != nil {
+			emptyPolls = 0
+			switch *resp.Data.Status {
+			case TaskStatusSuccess:
+				return nil
+			case TaskStatusFail:
+				return fmt.Errorf("drive task %s failed", taskId)
+			case TaskStatusProcess:
+			default:
+				return fmt.Errorf("drive task %s returned unknown status %q", taskId, *resp.Data.Status)
+			}
+		} else {
+			emptyPolls++
+			if emptyPolls >= fileTaskMaxEmptyPolls {
+				return fmt.Errorf("drive task %s returned no status after %d polls", taskId, emptyPolls)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(fileTaskPollInterval):
+		}
+	}
+}
+
+// 移动文件或文件夹，并等待异步任务结束
+func (f *file) MoveAndWait(ctx context.Context, fileToken string, fileType string, folderToken string, options ...larkcore.RequestOptionFunc) error {
+	resp, err := f.Move(ctx, NewMoveFileReqBuilder().
+		FileToken(fileToken).
+		Body(NewMoveFileReqBodyBuilder().
+			Type(fileType).
+			FolderToken(folderToken).
+			Build()).
+		Build(), options...)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return resp.CodeError
+	}
+	if resp.Data == nil || resp.Data.TaskId == nil {
+		return nil
+	}
+	return f.WaitTask(ctx, *resp.Data.TaskId, options...)
+}
+
+// 删除文件或文件夹，并等待异步任务结束
+func (f *file) DeleteAndWait(ctx context.Context, fileToken string, fileType string, options ...larkcore.RequestOptionFunc) error {
+	resp, err := f.Delete(ctx, NewDeleteFileReqBuilder().
+		FileToken(fileToken).
+		Type(fileType).
+		Build(), options...)
+	if err != nil {
+		return err
+	}
+	if !resp.Success() {
+		return resp.CodeError
+	}
+	if resp.Data == nil || resp.Data.TaskId == nil {
+		return nil
+	}
+	return f.WaitTask(ctx, *resp.Data.TaskId, options...)
+}
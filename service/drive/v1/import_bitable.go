@@ -0,0 +1,188 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	JobStatusImportTaskSuccess    = 0 // 导入成功
+	JobStatusImportTaskInit       = 1 // 初始化
+	JobStatusImportTaskProcessing = 2 // 处理中
+)
+
+const (
+	MountTypeExplorer = 1 // 挂载到云空间
+)
+
+// 导入任务轮询间隔
+const importTaskPollInterval = time.Second
+
+// 查询结果中连续缺少任务状态的最大次数，超过后放弃等待
+const importTaskMaxEmptyPolls = 10
+
+// ImportTaskError 导入任务执行失败
+type ImportTaskError struct {
+	Ticket      string
+	JobStatus   int
+	JobErrorMsg string
+}
+
+func (err *ImportTaskError) Error() string {
+	return fmt.Sprintf("import task %s failed, job_status:%d, job_error_msg:%s", err.Ticket, err.JobStatus, err.JobErrorMsg)
+}
+
+// ImportBitableResult 本地文件导入为多维表格的结果
+type ImportBitableResult struct {
+	Ticket   string   // 导入任务ID
+	AppToken string   // 新多维表格的 app token
+	Url      string   // 新多维表格的访问链接
+	Extra    []string // 导入成功后的提示信息
+}
+
+// 等待导入任务结束
+//
+// - 每隔一秒查询一次导入结果，直到任务成功、失败或 ctx 结束
+//
+// - 连续多次查询不到任务状态时返回错误
+//
+// - 任务失败时返回 *ImportTaskError
+func (i *importTask) Wait(ctx context.Context, ticket string, options ...larkcore.RequestOptionFunc) (*ImportTask, error) {
+	req := NewGetImportTaskReqBuilder().Ticket(ticket).Build()
+	emptyPolls := 0
+	for {
+		resp, err := i.Get(ctx, req, options...)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success() {
+			return nil, resp.CodeError
+		}
+		if resp.Data != nil && resp.Data.Result != nil && resp.Data.Result.JobStatus != nil {
+			task := resp.Data.Result
+			emptyPolls = 0
+			switch *task.JobStatus {
+			case JobStatusImportTaskSuccess:
+				return task, nil
+			case JobStatusImportTaskInit, JobStatusImportTaskProcessing:
+			default:
+				err := &ImportTaskError{Ticket: ticket, JobStatus: *task.JobStatus}
+				if task.JobErrorMsg != nil {
+					err.JobErrorMsg = *task.JobErrorMsg
+				}
+				return nil, err
+			}
+		} else {
+			emptyPolls++
+			if emptyPolls >= importTaskMaxEmptyPolls {
+				return nil, fmt.Errorf("import task %s returned no job status after %d polls", ticket, emptyPolls)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(importTaskPollInterval):
+		}
+	}
+}
+
+// 将本地 xlsx/csv 文件导入为多维表格
+//
+// - 先以 ccm_import_open 上传点上传文件，再创建导入任务，并轮询至任务结束
+//
+// - mountKey 为目标云空间文件夹 token，为空表示根目录
+//
+// - 返回新多维表格的 app token 和访问链接，可直接用于 BaseService
+func (i *importTask) ImportBitable(ctx context.Context, filePath string, mountKey string, options ...larkcore.RequestOptionFunc) (*ImportBitableResult, error) {
+	fileName := filepath.Base(filePath)
+	extension := strings.ToLower(strings.TrimPrefix(filepath.Ext(fileName), "."))
+	if extension != FileExtensionXlsx && extension != FileExtensionCsv && extension != "xls" {
+		return nil, fmt.Errorf("unsupported file extension %q, only xlsx, xls and csv can be imported as bitable", extension)
+	}
+
+	data, err := larkcore.File2Bytes(filePath)
+	if err != nil {
+		return nil, err
+	}
+	extra, err := json.Marshal(map[string]string{"obj_type": TypeBitable, "file_extension": extension})
+	if err != nil {
+		return nil, err
+	}
+
+	// 上传待导入文件
+	uploadResp, err := i.service.Media.UploadAll(ctx, NewUploadAllMediaReqBuilder().
+		Body(NewUploadAllMediaReqBodyBuilder().
+			FileName(fileName).
+			ParentType(ParentTypeUploadAllMediaCcmImportOpen).
+			Size(len(data)).
+			Extra(string(extra)).
+			File(bytes.NewReader(data)).
+			Build()).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	if !uploadResp.Success() {
+		return nil, uploadResp.CodeError
+	}
+	if uploadResp.Data == nil || uploadResp.Data.FileToken == nil {
+		return nil, fmt.Errorf("upload %s returned no file token", fileName)
+	}
+
+	// 创建导入任务
+	createResp, err := i.Create(ctx, NewCreateImportTaskReqBuilder().
+		ImportTask(NewImportTaskBuilder().
+			FileExtension(extension).
+			FileToken(*uploadResp.Data.FileToken).
+			Type(TypeBitable).
+			FileName(strings.TrimSuffix(fileName, filepath.Ext(fileName))).
+			Point(NewImportTaskMountPointBuilder().
+				MountType(MountTypeExplorer).
+				MountKey(mountKey).
+				Build()).
+			Build()).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	if !createResp.Success() {
+		return nil, createResp.CodeError
+	}
+
+	if createResp.Data == nil || createResp.Data.Ticket == nil {
+		return nil, fmt.Errorf("create import task for %s returned no ticket", fileName)
+	}
+	ticket := *createResp.Data.Ticket
+	task, err := i.Wait(ctx, ticket, options...)
+	if err != nil {
+		return nil, err
+	}
+	result := &ImportBitableResult{Ticket: ticket, Extra: task.Extra}
+	if task.Token != nil {
+		result.AppToken = *task.Token
+	}
+	if task.Url != nil {
+		result.Url = *task.Url
+	}
+	return result, nil
+}
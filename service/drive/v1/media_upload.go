@@ -0,0 +1,139 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 单次上传素材接口支持的最大文件大小，超过后使用分片上传
+const mediaUploadAllMaxSize = 20 * 1024 * 1024
+
+// 上传本地文件为素材
+//
+// - 文件不超过 20MB 时使用上传素材接口一次上传，否则走预上传、上传分片、完成上传的分片流程
+//
+// - 文件内容按分片从磁盘读取，不会整体加载到内存
+//
+// - parentType 和 parentNode 为上传点类型及其 token，例如 bitable_file 与多维表格的 app token
+//
+// - extra 用于开启了高级权限的多维表格，普通上传传空字符串即可
+//
+// - 返回新素材的 file_token
+func (m *media) UploadFile(ctx context.Context, filePath string, parentType string, parentNode string, extra string, options ...larkcore.RequestOptionFunc) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	size := int(stat.Size())
+	fileName := filepath.Base(filePath)
+	if size <= mediaUploadAllMaxSize {
+		builder := NewUploadAllMediaReqBodyBuilder().
+			FileName(fileName).
+			ParentType(parentType).
+			ParentNode(parentNode).
+			Size(size).
+			File(file)
+		if extra != "" {
+			builder.Extra(extra)
+		}
+		resp, err := m.UploadAll(ctx, NewUploadAllMediaReqBuilder().Body(builder.Build()).Build(), options...)
+		if err != nil {
+			return "", err
+		}
+		if !resp.Success() {
+			return "", resp.CodeError
+		}
+		if resp.Data == nil || resp.Data.FileToken == nil {
+			return "", fmt.Errorf("upload %s returned no file token", fileName)
+		}
+		return *resp.Data.FileToken, nil
+	}
+
+	// 预上传，获取上传事务ID和分片策略
+	info := NewMediaUploadInfoBuilder().
+		FileName(fileName).
+		ParentType(parentType).
+		ParentNode(parentNode).
+		Size(size)
+	if extra != "" {
+		info.Extra(extra)
+	}
+	prepareResp, err := m.UploadPrepare(ctx, NewUploadPrepareMediaReqBuilder().MediaUploadInfo(info.Build()).Build(), options...)
+	if err != nil {
+		return "", err
+	}
+	if !prepareResp.Success() {
+		return "", prepareResp.CodeError
+	}
+	if prepareResp.Data == nil || prepareResp.Data.UploadId == nil || prepareResp.Data.BlockSize == nil || prepareResp.Data.BlockNum == nil {
+		return "", fmt.Errorf("upload prepare of %s returned no upload_id or block info", fileName)
+	}
+	uploadId := *prepareResp.Data.UploadId
+	blockSize := *prepareResp.Data.BlockSize
+	blockNum := *prepareResp.Data.BlockNum
+	if blockSize <= 0 {
+		return "", fmt.Errorf("upload prepare of %s returned invalid block_size %d", fileName, blockSize)
+	}
+
+	// 按顺序上传各个分片
+	for seq := 0; seq < blockNum; seq++ {
+		start := seq * blockSize
+		end := start + blockSize
+		if end > size {
+			end = size
+		}
+		partResp, err := m.UploadPart(ctx, NewUploadPartMediaReqBuilder().
+			Body(NewUploadPartMediaReqBodyBuilder().
+				UploadId(uploadId).
+				Seq(seq).
+				Size(end-start).
+				File(io.NewSectionReader(file, int64(start), int64(end-start))).
+				Build()).
+			Build(), options...)
+		if err != nil {
+			return "", err
+		}
+		if !partResp.Success() {
+			return "", partResp.CodeError
+		}
+	}
+
+	finishResp, err := m.UploadFinish(ctx, NewUploadFinishMediaReqBuilder().
+		Body(NewUploadFinishMediaReqBodyBuilder().
+			UploadId(uploadId).
+			BlockNum(blockNum).
+			Build()).
+		Build(), options...)
+	if err != nil {
+		return "", err
+	}
+	if !finishResp.Success() {
+		return "", finishResp.CodeError
+	}
+	if finishResp.Data == nil || finishResp.Data.FileToken == nil {
+		return "", fmt.Errorf("upload finish of %s returned no file token", fileName)
+	}
+	return *finishResp.Data.FileToken, nil
+}
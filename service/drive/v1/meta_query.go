@@ -0,0 +1,121 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 单次获取元数据的文档数上限
+const metaBatchQueryLimit = 200
+
+// BatchQueryDocsResult 批量获取文档元数据的结果
+type BatchQueryDocsResult struct {
+	Metas  []*Meta       // 获取成功的文档元数据
+	Failed []*MetaFailed // 获取失败的文档及错误码
+}
+
+// DocInfo 文档的常用元数据
+type DocInfo struct {
+	Token            string    // 文档 token
+	Type             string    // 文档类型
+	Title            string    // 标题
+	OwnerId          string    // 所有者ID
+	Url              string    // 文档链接
+	CreateTime       time.Time // 创建时间
+	LatestModifyUser string    // 最后编辑者ID
+	LatestModifyTime time.Time // 最后编辑时间
+}
+
+// 批量获取文档元数据
+//
+// - 按单次 200 个自动分批请求，并合并各批次的成功与失败结果
+//
+// - 任一批次请求出错时立即返回 error
+func (m *meta) BatchQueryDocs(ctx context.Context, docs []*RequestDoc, withUrl bool, userIdType string, options ...larkcore.RequestOptionFunc) (*BatchQueryDocsResult, error) {
+	result := &BatchQueryDocsResult{}
+	for start := 0; start < len(docs); start += metaBatchQueryLimit {
+		end := start + metaBatchQueryLimit
+		if end > len(docs) {
+			end = len(docs)
+		}
+		builder := NewBatchQueryMetaReqBuilder().
+			MetaRequest(NewMetaRequestBuilder().
+				RequestDocs(docs[start:end]).
+				WithUrl(withUrl).
+				Build())
+		if userIdType != "" {
+			builder.UserIdType(userIdType)
+		}
+		resp, err := m.BatchQuery(ctx, builder.Build(), options...)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success() {
+			return nil, resp.CodeError
+		}
+		if resp.Data != nil {
+			result.Metas = append(result.Metas, resp.Data.Metas...)
+			result.Failed = append(result.Failed, resp.Data.FailedList...)
+		}
+	}
+	return result, nil
+}
+
+// 解析任意类型文档的标题、所有者、链接和修改时间
+//
+// - 返回值以文档 token 为 key，获取失败的文档不在结果中，可通过 BatchQueryDocsResult.Failed 查看原因
+func (m *meta) ResolveDocs(ctx context.Context, docs []*RequestDoc, options ...larkcore.RequestOptionFunc) (map[string]*DocInfo, *BatchQueryDocsResult, error) {
+	result, err := m.BatchQueryDocs(ctx, docs, true, UserIdTypeBatchQueryMetaOpenId, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	infos := make(map[string]*DocInfo, len(result.Metas))
+	for _, meta := range result.Metas {
+		info := &DocInfo{
+			Token:            stringValue(meta.DocToken),
+			Type:             stringValue(meta.DocType),
+			Title:            stringValue(meta.Title),
+			OwnerId:          stringValue(meta.OwnerId),
+			Url:              stringValue(meta.Url),
+			CreateTime:       unixStringTime(meta.CreateTime),
+			LatestModifyUser: stringValue(meta.LatestModifyUser),
+			LatestModifyTime: unixStringTime(meta.LatestModifyTime),
+		}
+		infos[info.Token] = info
+	}
+	return infos, result, nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// 解析秒级时间戳字符串，无法解析时返回零值
+func unixStringTime(s *string) time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(*s, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
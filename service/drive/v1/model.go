@@ -22,6 +22,9 @@ import (
 
 	"fmt"
 
+	"context"
+	"errors"
+
 	"github.com/larksuite/base-sdk-go/v3/core"
 )
 
@@ -4783,3 +4786,3770 @@ type UploadAllMediaResp struct {
 func (resp *UploadAllMediaResp) Success() bool {
 	return resp.Code == 0
 }
+
+type CreateImportTaskReqBuilder struct {
+	apiReq     *larkcore.ApiReq
+	importTask *ImportTask
+}
+
+func NewCreateImportTaskReqBuilder() *CreateImportTaskReqBuilder {
+	builder := &CreateImportTaskReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 创建导入任务。支持导入为 doc、docx、sheet、bitable，参考[导入用户指南](https://open.feishu.cn/document/ukTMukTMukTM/uATO2YjLwkjN24CM5YjN)
+func (builder *CreateImportTaskReqBuilder) ImportTask(importTask *ImportTask) *CreateImportTaskReqBuilder {
+	builder.importTask = importTask
+	return builder
+}
+
+func (builder *CreateImportTaskReqBuilder) Build() *CreateImportTaskReq {
+	req := &CreateImportTaskReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.Body = builder.importTask
+	return req
+}
+
+type CreateImportTaskReq struct {
+	apiReq     *larkcore.ApiReq
+	ImportTask *ImportTask `body:""`
+}
+
+type CreateImportTaskRespData struct {
+	Ticket *string `json:"ticket,omitempty"` // 导入任务ID
+}
+
+type CreateImportTaskResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CreateImportTaskRespData `json:"data"` // 业务数据
+}
+
+func (resp *CreateImportTaskResp) Success() bool {
+	return resp.Code == 0
+}
+
+type GetImportTaskReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewGetImportTaskReqBuilder() *GetImportTaskReqBuilder {
+	builder := &GetImportTaskReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 导入任务ID
+//
+// 示例值：6990281865xxxxxxxx7843
+func (builder *GetImportTaskReqBuilder) Ticket(ticket string) *GetImportTaskReqBuilder {
+	builder.apiReq.PathParams.Set("ticket", fmt.Sprint(ticket))
+	return builder
+}
+
+func (builder *GetImportTaskReqBuilder) Build() *GetImportTaskReq {
+	req := &GetImportTaskReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	return req
+}
+
+type GetImportTaskReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type GetImportTaskRespData struct {
+	Result *ImportTask `json:"result,omitempty"` // 导入结果
+}
+
+type GetImportTaskResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *GetImportTaskRespData `json:"data"` // 业务数据
+}
+
+func (resp *GetImportTaskResp) Success() bool {
+	return resp.Code == 0
+}
+
+type BatchQueryFileCommentReqBodyBuilder struct {
+	commentIds     []string // 需要获取的评论ID列表
+	commentIdsFlag bool
+}
+
+func NewBatchQueryFileCommentReqBodyBuilder() *BatchQueryFileCommentReqBodyBuilder {
+	builder := &BatchQueryFileCommentReqBodyBuilder{}
+	return builder
+}
+
+// 需要获取的评论ID列表
+//
+// 示例值：["1654857036541812356"]
+func (builder *BatchQueryFileCommentReqBodyBuilder) CommentIds(commentIds []string) *BatchQueryFileCommentReqBodyBuilder {
+	builder.commentIds = commentIds
+	builder.commentIdsFlag = true
+	return builder
+}
+
+func (builder *BatchQueryFileCommentReqBodyBuilder) Build() *BatchQueryFileCommentReqBody {
+	req := &BatchQueryFileCommentReqBody{}
+	if builder.commentIdsFlag {
+		req.CommentIds = builder.commentIds
+	}
+	return req
+}
+
+type BatchQueryFileCommentPathReqBodyBuilder struct {
+	commentIds     []string // 需要获取的评论ID列表
+	commentIdsFlag bool
+}
+
+func NewBatchQueryFileCommentPathReqBodyBuilder() *BatchQueryFileCommentPathReqBodyBuilder {
+	builder := &BatchQueryFileCommentPathReqBodyBuilder{}
+	return builder
+}
+
+// 需要获取的评论ID列表
+//
+// 示例值：["1654857036541812356"]
+func (builder *BatchQueryFileCommentPathReqBodyBuilder) CommentIds(commentIds []string) *BatchQueryFileCommentPathReqBodyBuilder {
+	builder.commentIds = commentIds
+	builder.commentIdsFlag = true
+	return builder
+}
+
+func (builder *BatchQueryFileCommentPathReqBodyBuilder) Build() (*BatchQueryFileCommentReqBody, error) {
+	req := &BatchQueryFileCommentReqBody{}
+	if builder.commentIdsFlag {
+		req.CommentIds = builder.commentIds
+	}
+	return req, nil
+}
+
+type BatchQueryFileCommentReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *BatchQueryFileCommentReqBody
+}
+
+func NewBatchQueryFileCommentReqBuilder() *BatchQueryFileCommentReqBuilder {
+	builder := &BatchQueryFileCommentReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *BatchQueryFileCommentReqBuilder) FileToken(fileToken string) *BatchQueryFileCommentReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *BatchQueryFileCommentReqBuilder) FileType(fileType string) *BatchQueryFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *BatchQueryFileCommentReqBuilder) UserIdType(userIdType string) *BatchQueryFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+// 该接口用于根据评论ID列表批量获取评论。
+func (builder *BatchQueryFileCommentReqBuilder) Body(body *BatchQueryFileCommentReqBody) *BatchQueryFileCommentReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *BatchQueryFileCommentReqBuilder) Build() *BatchQueryFileCommentReq {
+	req := &BatchQueryFileCommentReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type BatchQueryFileCommentReqBody struct {
+	CommentIds []string `json:"comment_ids,omitempty"` // 需要获取的评论ID列表
+}
+
+type BatchQueryFileCommentReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *BatchQueryFileCommentReqBody `body:""`
+}
+
+type BatchQueryFileCommentRespData struct {
+	Items []*FileComment `json:"items,omitempty"` // 评论的相关信息、回复的信息、回复分页的信息
+}
+
+type BatchQueryFileCommentResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *BatchQueryFileCommentRespData `json:"data"` // 业务数据
+}
+
+func (resp *BatchQueryFileCommentResp) Success() bool {
+	return resp.Code == 0
+}
+
+type CreateFileCommentReqBuilder struct {
+	apiReq      *larkcore.ApiReq
+	fileComment *FileComment
+}
+
+func NewCreateFileCommentReqBuilder() *CreateFileCommentReqBuilder {
+	builder := &CreateFileCommentReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *CreateFileCommentReqBuilder) FileToken(fileToken string) *CreateFileCommentReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *CreateFileCommentReqBuilder) FileType(fileType string) *CreateFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *CreateFileCommentReqBuilder) UserIdType(userIdType string) *CreateFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+// 往云文档添加一条全局评论。
+func (builder *CreateFileCommentReqBuilder) FileComment(fileComment *FileComment) *CreateFileCommentReqBuilder {
+	builder.fileComment = fileComment
+	return builder
+}
+
+func (builder *CreateFileCommentReqBuilder) Build() *CreateFileCommentReq {
+	req := &CreateFileCommentReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.fileComment
+	return req
+}
+
+type CreateFileCommentReq struct {
+	apiReq      *larkcore.ApiReq
+	FileComment *FileComment `body:""`
+}
+
+type CreateFileCommentRespData struct {
+	CommentId    *string    `json:"comment_id,omitempty"`     // 评论ID
+	UserId       *string    `json:"user_id,omitempty"`        // 用户ID
+	CreateTime   *int       `json:"create_time,omitempty"`    // 创建时间
+	UpdateTime   *int       `json:"update_time,omitempty"`    // 更新时间
+	IsSolved     *bool      `json:"is_solved,omitempty"`      // 是否已解决
+	SolvedTime   *int       `json:"solved_time,omitempty"`    // 解决评论时间
+	SolverUserId *string    `json:"solver_user_id,omitempty"` // 解决评论者的用户ID
+	HasMore      *bool      `json:"has_more,omitempty"`       // 是否有更多回复
+	PageToken    *string    `json:"page_token,omitempty"`     // 回复分页标记
+	IsWhole      *bool      `json:"is_whole,omitempty"`       // 是否是全文评论
+	Quote        *string    `json:"quote,omitempty"`          // 如果是局部评论，引用字段
+	ReplyList    *ReplyList `json:"reply_list,omitempty"`     // 评论里的回复列表
+}
+
+type CreateFileCommentResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CreateFileCommentRespData `json:"data"` // 业务数据
+}
+
+func (resp *CreateFileCommentResp) Success() bool {
+	return resp.Code == 0
+}
+
+type GetFileCommentReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewGetFileCommentReqBuilder() *GetFileCommentReqBuilder {
+	builder := &GetFileCommentReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *GetFileCommentReqBuilder) FileToken(fileToken string) *GetFileCommentReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 评论ID
+//
+// 示例值：6916106822734578184
+func (builder *GetFileCommentReqBuilder) CommentId(commentId string) *GetFileCommentReqBuilder {
+	builder.apiReq.PathParams.Set("comment_id", fmt.Sprint(commentId))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *GetFileCommentReqBuilder) FileType(fileType string) *GetFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *GetFileCommentReqBuilder) UserIdType(userIdType string) *GetFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+func (builder *GetFileCommentReqBuilder) Build() *GetFileCommentReq {
+	req := &GetFileCommentReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type GetFileCommentReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type GetFileCommentRespData struct {
+	CommentId    *string    `json:"comment_id,omitempty"`     // 评论ID
+	UserId       *string    `json:"user_id,omitempty"`        // 用户ID
+	CreateTime   *int       `json:"create_time,omitempty"`    // 创建时间
+	UpdateTime   *int       `json:"update_time,omitempty"`    // 更新时间
+	IsSolved     *bool      `json:"is_solved,omitempty"`      // 是否已解决
+	SolvedTime   *int       `json:"solved_time,omitempty"`    // 解决评论时间
+	SolverUserId *string    `json:"solver_user_id,omitempty"` // 解决评论者的用户ID
+	HasMore      *bool      `json:"has_more,omitempty"`       // 是否有更多回复
+	PageToken    *string    `json:"page_token,omitempty"`     // 回复分页标记
+	IsWhole      *bool      `json:"is_whole,omitempty"`       // 是否是全文评论
+	Quote        *string    `json:"quote,omitempty"`          // 如果是局部评论，引用字段
+	ReplyList    *ReplyList `json:"reply_list,omitempty"`     // 评论里的回复列表
+}
+
+type GetFileCommentResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *GetFileCommentRespData `json:"data"` // 业务数据
+}
+
+func (resp *GetFileCommentResp) Success() bool {
+	return resp.Code == 0
+}
+
+type ListFileCommentReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	limit  int // 最大返回多少记录，当使用迭代器访问时才有效
+}
+
+func NewListFileCommentReqBuilder() *ListFileCommentReqBuilder {
+	builder := &ListFileCommentReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 最大返回多少记录，当使用迭代器访问时才有效
+func (builder *ListFileCommentReqBuilder) Limit(limit int) *ListFileCommentReqBuilder {
+	builder.limit = limit
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *ListFileCommentReqBuilder) FileToken(fileToken string) *ListFileCommentReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *ListFileCommentReqBuilder) FileType(fileType string) *ListFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 是否全文评论
+//
+// 示例值：false
+func (builder *ListFileCommentReqBuilder) IsWhole(isWhole bool) *ListFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("is_whole", fmt.Sprint(isWhole))
+	return builder
+}
+
+// 是否已解决（可选）
+//
+// 示例值：false
+func (builder *ListFileCommentReqBuilder) IsSolved(isSolved bool) *ListFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("is_solved", fmt.Sprint(isSolved))
+	return builder
+}
+
+// 分页标记，第一次请求不填，表示从头开始遍历；分页查询结果还有更多项时会同时返回新的 page_token，下次遍历可采用该 page_token 获取查询结果
+//
+// 示例值：6916106822734578184
+func (builder *ListFileCommentReqBuilder) PageToken(pageToken string) *ListFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("page_token", fmt.Sprint(pageToken))
+	return builder
+}
+
+// 分页大小
+//
+// 示例值：10
+func (builder *ListFileCommentReqBuilder) PageSize(pageSize int) *ListFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("page_size", fmt.Sprint(pageSize))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *ListFileCommentReqBuilder) UserIdType(userIdType string) *ListFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+func (builder *ListFileCommentReqBuilder) Build() *ListFileCommentReq {
+	req := &ListFileCommentReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.Limit = builder.limit
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type ListFileCommentReq struct {
+	apiReq *larkcore.ApiReq
+	Limit  int // 最多返回多少记录，只有在使用迭代器访问时，才有效
+
+}
+
+type ListFileCommentRespData struct {
+	HasMore   *bool          `json:"has_more,omitempty"`   // 是否有下一页数据
+	PageToken *string        `json:"page_token,omitempty"` // 下一页分页的token
+	Items     []*FileComment `json:"items,omitempty"`      // 评论列表
+}
+
+type ListFileCommentResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *ListFileCommentRespData `json:"data"` // 业务数据
+}
+
+func (resp *ListFileCommentResp) Success() bool {
+	return resp.Code == 0
+}
+
+type PatchFileCommentReqBodyBuilder struct {
+	isSolved     bool // 评论解决标志
+	isSolvedFlag bool
+}
+
+func NewPatchFileCommentReqBodyBuilder() *PatchFileCommentReqBodyBuilder {
+	builder := &PatchFileCommentReqBodyBuilder{}
+	return builder
+}
+
+// 评论解决标志
+//
+// 示例值：true
+func (builder *PatchFileCommentReqBodyBuilder) IsSolved(isSolved bool) *PatchFileCommentReqBodyBuilder {
+	builder.isSolved = isSolved
+	builder.isSolvedFlag = true
+	return builder
+}
+
+func (builder *PatchFileCommentReqBodyBuilder) Build() *PatchFileCommentReqBody {
+	req := &PatchFileCommentReqBody{}
+	if builder.isSolvedFlag {
+		req.IsSolved = &builder.isSolved
+	}
+	return req
+}
+
+type PatchFileCommentPathReqBodyBuilder struct {
+	isSolved     bool // 评论解决标志
+	isSolvedFlag bool
+}
+
+func NewPatchFileCommentPathReqBodyBuilder() *PatchFileCommentPathReqBodyBuilder {
+	builder := &PatchFileCommentPathReqBodyBuilder{}
+	return builder
+}
+
+// 评论解决标志
+//
+// 示例值：true
+func (builder *PatchFileCommentPathReqBodyBuilder) IsSolved(isSolved bool) *PatchFileCommentPathReqBodyBuilder {
+	builder.isSolved = isSolved
+	builder.isSolvedFlag = true
+	return builder
+}
+
+func (builder *PatchFileCommentPathReqBodyBuilder) Build() (*PatchFileCommentReqBody, error) {
+	req := &PatchFileCommentReqBody{}
+	if builder.isSolvedFlag {
+		req.IsSolved = &builder.isSolved
+	}
+	return req, nil
+}
+
+type PatchFileCommentReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *PatchFileCommentReqBody
+}
+
+func NewPatchFileCommentReqBuilder() *PatchFileCommentReqBuilder {
+	builder := &PatchFileCommentReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *PatchFileCommentReqBuilder) FileToken(fileToken string) *PatchFileCommentReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 评论ID
+//
+// 示例值：6916106822734578184
+func (builder *PatchFileCommentReqBuilder) CommentId(commentId string) *PatchFileCommentReqBuilder {
+	builder.apiReq.PathParams.Set("comment_id", fmt.Sprint(commentId))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *PatchFileCommentReqBuilder) FileType(fileType string) *PatchFileCommentReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 解决或恢复云文档中的评论。
+func (builder *PatchFileCommentReqBuilder) Body(body *PatchFileCommentReqBody) *PatchFileCommentReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *PatchFileCommentReqBuilder) Build() *PatchFileCommentReq {
+	req := &PatchFileCommentReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type PatchFileCommentReqBody struct {
+	IsSolved *bool `json:"is_solved,omitempty"` // 评论解决标志
+}
+
+type PatchFileCommentReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *PatchFileCommentReqBody `body:""`
+}
+
+type PatchFileCommentResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (resp *PatchFileCommentResp) Success() bool {
+	return resp.Code == 0
+}
+
+type DeleteFileCommentReplyReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewDeleteFileCommentReplyReqBuilder() *DeleteFileCommentReplyReqBuilder {
+	builder := &DeleteFileCommentReplyReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *DeleteFileCommentReplyReqBuilder) FileToken(fileToken string) *DeleteFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 评论ID
+//
+// 示例值：6916106822734578184
+func (builder *DeleteFileCommentReplyReqBuilder) CommentId(commentId string) *DeleteFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("comment_id", fmt.Sprint(commentId))
+	return builder
+}
+
+// 回复ID
+//
+// 示例值：6916106822734594568
+func (builder *DeleteFileCommentReplyReqBuilder) ReplyId(replyId string) *DeleteFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("reply_id", fmt.Sprint(replyId))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *DeleteFileCommentReplyReqBuilder) FileType(fileType string) *DeleteFileCommentReplyReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+func (builder *DeleteFileCommentReplyReqBuilder) Build() *DeleteFileCommentReplyReq {
+	req := &DeleteFileCommentReplyReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type DeleteFileCommentReplyReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type DeleteFileCommentReplyResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (resp *DeleteFileCommentReplyResp) Success() bool {
+	return resp.Code == 0
+}
+
+type ListFileCommentReplyReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	limit  int // 最大返回多少记录，当使用迭代器访问时才有效
+}
+
+func NewListFileCommentReplyReqBuilder() *ListFileCommentReplyReqBuilder {
+	builder := &ListFileCommentReplyReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 最大返回多少记录，当使用迭代器访问时才有效
+func (builder *ListFileCommentReplyReqBuilder) Limit(limit int) *ListFileCommentReplyReqBuilder {
+	builder.limit = limit
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *ListFileCommentReplyReqBuilder) FileToken(fileToken string) *ListFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 评论ID
+//
+// 示例值：6916106822734578184
+func (builder *ListFileCommentReplyReqBuilder) CommentId(commentId string) *ListFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("comment_id", fmt.Sprint(commentId))
+	return builder
+}
+
+// 分页大小
+//
+// 示例值：10
+func (builder *ListFileCommentReplyReqBuilder) PageSize(pageSize int) *ListFileCommentReplyReqBuilder {
+	builder.apiReq.QueryParams.Set("page_size", fmt.Sprint(pageSize))
+	return builder
+}
+
+// 分页标记，第一次请求不填，表示从头开始遍历；分页查询结果还有更多项时会同时返回新的 page_token，下次遍历可采用该 page_token 获取查询结果
+//
+// 示例值：6916106822734578184
+func (builder *ListFileCommentReplyReqBuilder) PageToken(pageToken string) *ListFileCommentReplyReqBuilder {
+	builder.apiReq.QueryParams.Set("page_token", fmt.Sprint(pageToken))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *ListFileCommentReplyReqBuilder) FileType(fileType string) *ListFileCommentReplyReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *ListFileCommentReplyReqBuilder) UserIdType(userIdType string) *ListFileCommentReplyReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+func (builder *ListFileCommentReplyReqBuilder) Build() *ListFileCommentReplyReq {
+	req := &ListFileCommentReplyReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.Limit = builder.limit
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type ListFileCommentReplyReq struct {
+	apiReq *larkcore.ApiReq
+	Limit  int // 最多返回多少记录，只有在使用迭代器访问时，才有效
+
+}
+
+type ListFileCommentReplyRespData struct {
+	Items     []*FileCommentReply `json:"items,omitempty"`      // 回复列表
+	PageToken *string             `json:"page_token,omitempty"` // 下一页分页的token
+	HasMore   *bool               `json:"has_more,omitempty"`   // 是否有下一页数据
+}
+
+type ListFileCommentReplyResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *ListFileCommentReplyRespData `json:"data"` // 业务数据
+}
+
+func (resp *ListFileCommentReplyResp) Success() bool {
+	return resp.Code == 0
+}
+
+type UpdateFileCommentReplyReqBodyBuilder struct {
+	content     *ReplyContent // 回复内容
+	contentFlag bool
+}
+
+func NewUpdateFileCommentReplyReqBodyBuilder() *UpdateFileCommentReplyReqBodyBuilder {
+	builder := &UpdateFileCommentReplyReqBodyBuilder{}
+	return builder
+}
+
+// 回复内容
+//
+// 示例值：
+func (builder *UpdateFileCommentReplyReqBodyBuilder) Content(content *ReplyContent) *UpdateFileCommentReplyReqBodyBuilder {
+	builder.content = content
+	builder.contentFlag = true
+	return builder
+}
+
+func (builder *UpdateFileCommentReplyReqBodyBuilder) Build() *UpdateFileCommentReplyReqBody {
+	req := &UpdateFileCommentReplyReqBody{}
+	if builder.contentFlag {
+		req.Content = builder.content
+	}
+	return req
+}
+
+type UpdateFileCommentReplyPathReqBodyBuilder struct {
+	content     *ReplyContent // 回复内容
+	contentFlag bool
+}
+
+func NewUpdateFileCommentReplyPathReqBodyBuilder() *UpdateFileCommentReplyPathReqBodyBuilder {
+	builder := &UpdateFileCommentReplyPathReqBodyBuilder{}
+	return builder
+}
+
+// 回复内容
+//
+// 示例值：
+func (builder *UpdateFileCommentReplyPathReqBodyBuilder) Content(content *ReplyContent) *UpdateFileCommentReplyPathReqBodyBuilder {
+	builder.content = content
+	builder.contentFlag = true
+	return builder
+}
+
+func (builder *UpdateFileCommentReplyPathReqBodyBuilder) Build() (*UpdateFileCommentReplyReqBody, error) {
+	req := &UpdateFileCommentReplyReqBody{}
+	if builder.contentFlag {
+		req.Content = builder.content
+	}
+	return req, nil
+}
+
+type UpdateFileCommentReplyReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *UpdateFileCommentReplyReqBody
+}
+
+func NewUpdateFileCommentReplyReqBuilder() *UpdateFileCommentReplyReqBuilder {
+	builder := &UpdateFileCommentReplyReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxbcdl03Vsxhm7Qmnj110abcef
+func (builder *UpdateFileCommentReplyReqBuilder) FileToken(fileToken string) *UpdateFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 评论ID
+//
+// 示例值：6916106822734578184
+func (builder *UpdateFileCommentReplyReqBuilder) CommentId(commentId string) *UpdateFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("comment_id", fmt.Sprint(commentId))
+	return builder
+}
+
+// 回复ID
+//
+// 示例值：6916106822734594568
+func (builder *UpdateFileCommentReplyReqBuilder) ReplyId(replyId string) *UpdateFileCommentReplyReqBuilder {
+	builder.apiReq.PathParams.Set("reply_id", fmt.Sprint(replyId))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：doc
+func (builder *UpdateFileCommentReplyReqBuilder) FileType(fileType string) *UpdateFileCommentReplyReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *UpdateFileCommentReplyReqBuilder) UserIdType(userIdType string) *UpdateFileCommentReplyReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+// 更新云文档中的某条回复。
+func (builder *UpdateFileCommentReplyReqBuilder) Body(body *UpdateFileCommentReplyReqBody) *UpdateFileCommentReplyReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *UpdateFileCommentReplyReqBuilder) Build() *UpdateFileCommentReplyReq {
+	req := &UpdateFileCommentReplyReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type UpdateFileCommentReplyReqBody struct {
+	Content *ReplyContent `json:"content,omitempty"` // 回复内容
+}
+
+type UpdateFileCommentReplyReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *UpdateFileCommentReplyReqBody `body:""`
+}
+
+type UpdateFileCommentReplyResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (resp *UpdateFileCommentReplyResp) Success() bool {
+	return resp.Code == 0
+}
+
+type CreatePermissionMemberReqBuilder struct {
+	apiReq     *larkcore.ApiReq
+	baseMember *BaseMember
+}
+
+func NewCreatePermissionMemberReqBuilder() *CreatePermissionMemberReqBuilder {
+	builder := &CreatePermissionMemberReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件的 token，获取方式见 [如何获取云文档资源相关 token](https://open.feishu.cn/document/ukTMukTMukTM/uczNzUjL3czM14yN3MTN#08bb5df6)
+//
+// 示例值：doccnBKgoMyY5OMbUG6FioTXuBe
+func (builder *CreatePermissionMemberReqBuilder) Token(token string) *CreatePermissionMemberReqBuilder {
+	builder.apiReq.PathParams.Set("token", fmt.Sprint(token))
+	return builder
+}
+
+// 文件类型，需要与文件的 token 相匹配
+//
+// 示例值：bitable
+func (builder *CreatePermissionMemberReqBuilder) Type(type_ string) *CreatePermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+// 添加权限后是否通知对方
+//
+// 示例值：false
+func (builder *CreatePermissionMemberReqBuilder) NeedNotification(needNotification bool) *CreatePermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("need_notification", fmt.Sprint(needNotification))
+	return builder
+}
+
+// 该接口用于根据 filetoken 给用户增加文档的权限。
+func (builder *CreatePermissionMemberReqBuilder) BaseMember(baseMember *BaseMember) *CreatePermissionMemberReqBuilder {
+	builder.baseMember = baseMember
+	return builder
+}
+
+func (builder *CreatePermissionMemberReqBuilder) Build() *CreatePermissionMemberReq {
+	req := &CreatePermissionMemberReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.baseMember
+	return req
+}
+
+type CreatePermissionMemberReq struct {
+	apiReq     *larkcore.ApiReq
+	BaseMember *BaseMember `body:""`
+}
+
+type CreatePermissionMemberRespData struct {
+	Member *BaseMember `json:"member,omitempty"` // 本次添加权限的用户信息
+}
+
+type CreatePermissionMemberResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CreatePermissionMemberRespData `json:"data"` // 业务数据
+}
+
+func (resp *CreatePermissionMemberResp) Success() bool {
+	return resp.Code == 0
+}
+
+type DeletePermissionMemberReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewDeletePermissionMemberReqBuilder() *DeletePermissionMemberReqBuilder {
+	builder := &DeletePermissionMemberReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件的 token，获取方式见 [如何获取云文档资源相关 token](https://open.feishu.cn/document/ukTMukTMukTM/uczNzUjL3czM14yN3MTN#08bb5df6)
+//
+// 示例值：doccnBKgoMyY5OMbUG6FioTXuBe
+func (builder *DeletePermissionMemberReqBuilder) Token(token string) *DeletePermissionMemberReqBuilder {
+	builder.apiReq.PathParams.Set("token", fmt.Sprint(token))
+	return builder
+}
+
+// 权限成员的ID，与`member_type`相对应
+//
+// 示例值：ou_7dab8a3d3cdcc9da365777c7ad535d62
+func (builder *DeletePermissionMemberReqBuilder) MemberId(memberId string) *DeletePermissionMemberReqBuilder {
+	builder.apiReq.PathParams.Set("member_id", fmt.Sprint(memberId))
+	return builder
+}
+
+// 文件类型，需要与文件的 token 相匹配
+//
+// 示例值：bitable
+func (builder *DeletePermissionMemberReqBuilder) Type(type_ string) *DeletePermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+// 权限成员的ID类型，与`member_id`相对应
+//
+// 示例值：openid
+func (builder *DeletePermissionMemberReqBuilder) MemberType(memberType string) *DeletePermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("member_type", fmt.Sprint(memberType))
+	return builder
+}
+
+func (builder *DeletePermissionMemberReqBuilder) Build() *DeletePermissionMemberReq {
+	req := &DeletePermissionMemberReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type DeletePermissionMemberReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type DeletePermissionMemberResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (resp *DeletePermissionMemberResp) Success() bool {
+	return resp.Code == 0
+}
+
+type ListPermissionMemberReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewListPermissionMemberReqBuilder() *ListPermissionMemberReqBuilder {
+	builder := &ListPermissionMemberReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件的 token，获取方式见 [如何获取云文档资源相关 token](https://open.feishu.cn/document/ukTMukTMukTM/uczNzUjL3czM14yN3MTN#08bb5df6)
+//
+// 示例值：doccnBKgoMyY5OMbUG6FioTXuBe
+func (builder *ListPermissionMemberReqBuilder) Token(token string) *ListPermissionMemberReqBuilder {
+	builder.apiReq.PathParams.Set("token", fmt.Sprint(token))
+	return builder
+}
+
+// 文件类型，需要与文件的 token 相匹配
+//
+// 示例值：bitable
+func (builder *ListPermissionMemberReqBuilder) Type(type_ string) *ListPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+// 指定返回的协作者字段信息，如无指定则默认不返回；;**可选值有：** ;- `name`：协作者名;- `type`：协作者类型;- `avatar`：头像;- `external_label`：外部标签;;**注意：** ;- 你可以使用特殊值`*`指定返回目前支持的所有字段;- 你可以使用`,`分隔若干个你想指定返回的字段，如：`name,avatar`;- 按需指定返回字段接口性能更好
+//
+// 示例值：*
+func (builder *ListPermissionMemberReqBuilder) Fields(fields string) *ListPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("fields", fmt.Sprint(fields))
+	return builder
+}
+
+// 协作者的权限角色类型
+//
+// 示例值：container
+func (builder *ListPermissionMemberReqBuilder) PermType(permType string) *ListPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("perm_type", fmt.Sprint(permType))
+	return builder
+}
+
+func (builder *ListPermissionMemberReqBuilder) Build() *ListPermissionMemberReq {
+	req := &ListPermissionMemberReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type ListPermissionMemberReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type ListPermissionMemberRespData struct {
+	Items []*Member `json:"items,omitempty"` // 返回的列表数据
+}
+
+type ListPermissionMemberResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *ListPermissionMemberRespData `json:"data"` // 业务数据
+}
+
+func (resp *ListPermissionMemberResp) Success() bool {
+	return resp.Code == 0
+}
+
+type TransferOwnerPermissionMemberReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	owner  *Owner
+}
+
+func NewTransferOwnerPermissionMemberReqBuilder() *TransferOwnerPermissionMemberReqBuilder {
+	builder := &TransferOwnerPermissionMemberReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件的 token，获取方式见 [如何获取云文档资源相关 token](https://open.feishu.cn/document/ukTMukTMukTM/uczNzUjL3czM14yN3MTN#08bb5df6)
+//
+// 示例值：doccnBKgoMyY5OMbUG6FioTXuBe
+func (builder *TransferOwnerPermissionMemberReqBuilder) Token(token string) *TransferOwnerPermissionMemberReqBuilder {
+	builder.apiReq.PathParams.Set("token", fmt.Sprint(token))
+	return builder
+}
+
+// 文件类型，需要与文件的 token 相匹配
+//
+// 示例值：bitable
+func (builder *TransferOwnerPermissionMemberReqBuilder) Type(type_ string) *TransferOwnerPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+// 是否需要通知新的文档所有者
+//
+// 示例值：true
+func (builder *TransferOwnerPermissionMemberReqBuilder) NeedNotification(needNotification bool) *TransferOwnerPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("need_notification", fmt.Sprint(needNotification))
+	return builder
+}
+
+// 转移后是否需要移除原文档所有者的权限
+//
+// 示例值：false
+func (builder *TransferOwnerPermissionMemberReqBuilder) RemoveOldOwner(removeOldOwner bool) *TransferOwnerPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("remove_old_owner", fmt.Sprint(removeOldOwner))
+	return builder
+}
+
+// 仅当内容不在共享文件夹中，此参数才会生效。如果设为false，系统会将该内容移至新所有者的个人空间根文件夹。如果设为 true，则留在原位置。
+//
+// 示例值：false
+func (builder *TransferOwnerPermissionMemberReqBuilder) StayPut(stayPut bool) *TransferOwnerPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("stay_put", fmt.Sprint(stayPut))
+	return builder
+}
+
+// 仅当 remove_old_owner = false 时，此参数才会生效 保留原文档所有者指定的权限角色
+//
+// 示例值：full_access
+func (builder *TransferOwnerPermissionMemberReqBuilder) OldOwnerPerm(oldOwnerPerm string) *TransferOwnerPermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("old_owner_perm", fmt.Sprint(oldOwnerPerm))
+	return builder
+}
+
+// 该接口用于根据文档信息和用户信息转移文档的所有者。
+func (builder *TransferOwnerPermissionMemberReqBuilder) Owner(owner *Owner) *TransferOwnerPermissionMemberReqBuilder {
+	builder.owner = owner
+	return builder
+}
+
+func (builder *TransferOwnerPermissionMemberReqBuilder) Build() *TransferOwnerPermissionMemberReq {
+	req := &TransferOwnerPermissionMemberReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.owner
+	return req
+}
+
+type TransferOwnerPermissionMemberReq struct {
+	apiReq *larkcore.ApiReq
+	Owner  *Owner `body:""`
+}
+
+type TransferOwnerPermissionMemberResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (resp *TransferOwnerPermissionMemberResp) Success() bool {
+	return resp.Code == 0
+}
+
+type UpdatePermissionMemberReqBuilder struct {
+	apiReq     *larkcore.ApiReq
+	baseMember *BaseMember
+}
+
+func NewUpdatePermissionMemberReqBuilder() *UpdatePermissionMemberReqBuilder {
+	builder := &UpdatePermissionMemberReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件的 token，获取方式见 [如何获取云文档资源相关 token](https://open.feishu.cn/document/ukTMukTMukTM/uczNzUjL3czM14yN3MTN#08bb5df6)
+//
+// 示例值：doccnBKgoMyY5OMbUG6FioTXuBe
+func (builder *UpdatePermissionMemberReqBuilder) Token(token string) *UpdatePermissionMemberReqBuilder {
+	builder.apiReq.PathParams.Set("token", fmt.Sprint(token))
+	return builder
+}
+
+// 权限成员的ID，与`member_type`相对应
+//
+// 示例值：ou_7dab8a3d3cdcc9da365777c7ad535d62
+func (builder *UpdatePermissionMemberReqBuilder) MemberId(memberId string) *UpdatePermissionMemberReqBuilder {
+	builder.apiReq.PathParams.Set("member_id", fmt.Sprint(memberId))
+	return builder
+}
+
+// 添加权限后是否通知对方
+//
+// 示例值：false
+func (builder *UpdatePermissionMemberReqBuilder) NeedNotification(needNotification bool) *UpdatePermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("need_notification", fmt.Sprint(needNotification))
+	return builder
+}
+
+// 文件类型，需要与文件的 token 相匹配
+//
+// 示例值：bitable
+func (builder *UpdatePermissionMemberReqBuilder) Type(type_ string) *UpdatePermissionMemberReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+// 该接口用于根据 filetoken 更新文档协作者的权限。
+func (builder *UpdatePermissionMemberReqBuilder) BaseMember(baseMember *BaseMember) *UpdatePermissionMemberReqBuilder {
+	builder.baseMember = baseMember
+	return builder
+}
+
+func (builder *UpdatePermissionMemberReqBuilder) Build() *UpdatePermissionMemberReq {
+	req := &UpdatePermissionMemberReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.baseMember
+	return req
+}
+
+type UpdatePermissionMemberReq struct {
+	apiReq     *larkcore.ApiReq
+	BaseMember *BaseMember `body:""`
+}
+
+type UpdatePermissionMemberRespData struct {
+	Member *BaseMember `json:"member,omitempty"` // 本次更新权限的用户信息
+}
+
+type UpdatePermissionMemberResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *UpdatePermissionMemberRespData `json:"data"` // 业务数据
+}
+
+func (resp *UpdatePermissionMemberResp) Success() bool {
+	return resp.Code == 0
+}
+
+type GetPermissionPublicReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewGetPermissionPublicReqBuilder() *GetPermissionPublicReqBuilder {
+	builder := &GetPermissionPublicReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件的 token，获取方式见 [如何获取云文档资源相关 token](https://open.feishu.cn/document/ukTMukTMukTM/uczNzUjL3czM14yN3MTN#08bb5df6)
+//
+// 示例值：doccnBKgoMyY5OMbUG6FioTXuBe
+func (builder *GetPermissionPublicReqBuilder) Token(token string) *GetPermissionPublicReqBuilder {
+	builder.apiReq.PathParams.Set("token", fmt.Sprint(token))
+	return builder
+}
+
+// 文件类型，需要与文件的 token 相匹配
+//
+// 示例值：bitable
+func (builder *GetPermissionPublicReqBuilder) Type(type_ string) *GetPermissionPublicReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+func (builder *GetPermissionPublicReqBuilder) Build() *GetPermissionPublicReq {
+	req := &GetPermissionPublicReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type GetPermissionPublicReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type GetPermissionPublicRespData struct {
+	PermissionPublic *PermissionPublic `json:"permission_public,omitempty"` // 返回的文档权限设置
+}
+
+type GetPermissionPublicResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *GetPermissionPublicRespData `json:"data"` // 业务数据
+}
+
+func (resp *GetPermissionPublicResp) Success() bool {
+	return resp.Code == 0
+}
+
+type PatchPermissionPublicReqBuilder struct {
+	apiReq                  *larkcore.ApiReq
+	permissionPublicRequest *PermissionPublicRequest
+}
+
+func NewPatchPermissionPublicReqBuilder() *PatchPermissionPublicReqBuilder {
+	builder := &PatchPermissionPublicReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件的 token，获取方式见 [如何获取云文档资源相关 token](https://open.feishu.cn/document/ukTMukTMukTM/uczNzUjL3czM14yN3MTN#08bb5df6)
+//
+// 示例值：doccnBKgoMyY5OMbUG6FioTXuBe
+func (builder *PatchPermissionPublicReqBuilder) Token(token string) *PatchPermissionPublicReqBuilder {
+	builder.apiReq.PathParams.Set("token", fmt.Sprint(token))
+	return builder
+}
+
+// 文件类型，需要与文件的 token 相匹配
+//
+// 示例值：bitable
+func (builder *PatchPermissionPublicReqBuilder) Type(type_ string) *PatchPermissionPublicReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+// 该接口用于根据 filetoken 更新云文档的权限设置。
+func (builder *PatchPermissionPublicReqBuilder) PermissionPublicRequest(permissionPublicRequest *PermissionPublicRequest) *PatchPermissionPublicReqBuilder {
+	builder.permissionPublicRequest = permissionPublicRequest
+	return builder
+}
+
+func (builder *PatchPermissionPublicReqBuilder) Build() *PatchPermissionPublicReq {
+	req := &PatchPermissionPublicReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.permissionPublicRequest
+	return req
+}
+
+type PatchPermissionPublicReq struct {
+	apiReq                  *larkcore.ApiReq
+	PermissionPublicRequest *PermissionPublicRequest `body:""`
+}
+
+type PatchPermissionPublicRespData struct {
+	PermissionPublic *PermissionPublic `json:"permission_public,omitempty"` // 本次更新后的文档权限设置
+}
+
+type PatchPermissionPublicResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *PatchPermissionPublicRespData `json:"data"` // 业务数据
+}
+
+func (resp *PatchPermissionPublicResp) Success() bool {
+	return resp.Code == 0
+}
+
+type CreateFileVersionReqBuilder struct {
+	apiReq  *larkcore.ApiReq
+	version *Version
+}
+
+func NewCreateFileVersionReqBuilder() *CreateFileVersionReqBuilder {
+	builder := &CreateFileVersionReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 源文档token
+//
+// 示例值：shtbcqqoXZJaKYrfN5IHQg4sBgh
+func (builder *CreateFileVersionReqBuilder) FileToken(fileToken string) *CreateFileVersionReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *CreateFileVersionReqBuilder) UserIdType(userIdType string) *CreateFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+// 创建文档版本。
+func (builder *CreateFileVersionReqBuilder) Version(version *Version) *CreateFileVersionReqBuilder {
+	builder.version = version
+	return builder
+}
+
+func (builder *CreateFileVersionReqBuilder) Build() *CreateFileVersionReq {
+	req := &CreateFileVersionReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.version
+	return req
+}
+
+type CreateFileVersionReq struct {
+	apiReq  *larkcore.ApiReq
+	Version *Version `body:""`
+}
+
+type CreateFileVersionRespData struct {
+	Name        *string `json:"name,omitempty"`         // 版本文档标题
+	Version     *string `json:"version,omitempty"`      // 版本文档版本号
+	ParentToken *string `json:"parent_token,omitempty"` // shtbcpM2mm3znrLfWnf4browTYp
+	OwnerId     *string `json:"owner_id,omitempty"`     // 版本文档所有者id
+	CreatorId   *string `json:"creator_id,omitempty"`   // 版本文档创建者id
+	CreateTime  *string `json:"create_time,omitempty"`  // 版本文档创建时间
+	UpdateTime  *string `json:"update_time,omitempty"`  // 版本文档更新时间
+	Status      *string `json:"status,omitempty"`       // 版本文档状态
+	ObjType     *string `json:"obj_type,omitempty"`     // 版本文档类型
+	ParentType  *string `json:"parent_type,omitempty"`  // 源文档类型
+}
+
+type CreateFileVersionResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CreateFileVersionRespData `json:"data"` // 业务数据
+}
+
+func (resp *CreateFileVersionResp) Success() bool {
+	return resp.Code == 0
+}
+
+type DeleteFileVersionReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewDeleteFileVersionReqBuilder() *DeleteFileVersionReqBuilder {
+	builder := &DeleteFileVersionReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 源文档token
+//
+// 示例值：shtbcqqoXZJaKYrfN5IHQg4sBgh
+func (builder *DeleteFileVersionReqBuilder) FileToken(fileToken string) *DeleteFileVersionReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 版本文档版本号
+//
+// 示例值：fnJfyX
+func (builder *DeleteFileVersionReqBuilder) VersionId(versionId string) *DeleteFileVersionReqBuilder {
+	builder.apiReq.PathParams.Set("version_id", fmt.Sprint(versionId))
+	return builder
+}
+
+// 原文档类型
+//
+// 示例值：bitable
+func (builder *DeleteFileVersionReqBuilder) ObjType(objType string) *DeleteFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("obj_type", fmt.Sprint(objType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *DeleteFileVersionReqBuilder) UserIdType(userIdType string) *DeleteFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+func (builder *DeleteFileVersionReqBuilder) Build() *DeleteFileVersionReq {
+	req := &DeleteFileVersionReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type DeleteFileVersionReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type DeleteFileVersionResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (resp *DeleteFileVersionResp) Success() bool {
+	return resp.Code == 0
+}
+
+type GetFileVersionReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewGetFileVersionReqBuilder() *GetFileVersionReqBuilder {
+	builder := &GetFileVersionReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 源文档token
+//
+// 示例值：shtbcqqoXZJaKYrfN5IHQg4sBgh
+func (builder *GetFileVersionReqBuilder) FileToken(fileToken string) *GetFileVersionReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 版本文档版本号
+//
+// 示例值：fnJfyX
+func (builder *GetFileVersionReqBuilder) VersionId(versionId string) *GetFileVersionReqBuilder {
+	builder.apiReq.PathParams.Set("version_id", fmt.Sprint(versionId))
+	return builder
+}
+
+// 原文档类型
+//
+// 示例值：bitable
+func (builder *GetFileVersionReqBuilder) ObjType(objType string) *GetFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("obj_type", fmt.Sprint(objType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *GetFileVersionReqBuilder) UserIdType(userIdType string) *GetFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+func (builder *GetFileVersionReqBuilder) Build() *GetFileVersionReq {
+	req := &GetFileVersionReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type GetFileVersionReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type GetFileVersionRespData struct {
+	Name        *string `json:"name,omitempty"`         // 版本文档标题
+	Version     *string `json:"version,omitempty"`      // 版本文档版本号
+	ParentToken *string `json:"parent_token,omitempty"` // shtbcpM2mm3znrLfWnf4browTYp
+	OwnerId     *string `json:"owner_id,omitempty"`     // 版本文档所有者id
+	CreatorId   *string `json:"creator_id,omitempty"`   // 版本文档创建者id
+	CreateTime  *string `json:"create_time,omitempty"`  // 版本文档创建时间
+	UpdateTime  *string `json:"update_time,omitempty"`  // 版本文档更新时间
+	Status      *string `json:"status,omitempty"`       // 版本文档状态
+	ObjType     *string `json:"obj_type,omitempty"`     // 版本文档类型
+	ParentType  *string `json:"parent_type,omitempty"`  // 源文档类型
+}
+
+type GetFileVersionResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *GetFileVersionRespData `json:"data"` // 业务数据
+}
+
+func (resp *GetFileVersionResp) Success() bool {
+	return resp.Code == 0
+}
+
+type ListFileVersionReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	limit  int // 最大返回多少记录，当使用迭代器访问时才有效
+}
+
+func NewListFileVersionReqBuilder() *ListFileVersionReqBuilder {
+	builder := &ListFileVersionReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 最大返回多少记录，当使用迭代器访问时才有效
+func (builder *ListFileVersionReqBuilder) Limit(limit int) *ListFileVersionReqBuilder {
+	builder.limit = limit
+	return builder
+}
+
+// 源文档token
+//
+// 示例值：shtbcqqoXZJaKYrfN5IHQg4sBgh
+func (builder *ListFileVersionReqBuilder) FileToken(fileToken string) *ListFileVersionReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 分页大小
+//
+// 示例值：10
+func (builder *ListFileVersionReqBuilder) PageSize(pageSize int) *ListFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("page_size", fmt.Sprint(pageSize))
+	return builder
+}
+
+// 分页标记，第一次请求不填，表示从头开始遍历；分页查询结果还有更多项时会同时返回新的 page_token，下次遍历可采用该 page_token 获取查询结果
+//
+// 示例值：1665739388
+func (builder *ListFileVersionReqBuilder) PageToken(pageToken string) *ListFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("page_token", fmt.Sprint(pageToken))
+	return builder
+}
+
+// 原文档类型
+//
+// 示例值：bitable
+func (builder *ListFileVersionReqBuilder) ObjType(objType string) *ListFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("obj_type", fmt.Sprint(objType))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *ListFileVersionReqBuilder) UserIdType(userIdType string) *ListFileVersionReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+func (builder *ListFileVersionReqBuilder) Build() *ListFileVersionReq {
+	req := &ListFileVersionReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.Limit = builder.limit
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type ListFileVersionReq struct {
+	apiReq *larkcore.ApiReq
+	Limit  int // 最多返回多少记录，只有在使用迭代器访问时，才有效
+
+}
+
+type ListFileVersionRespData struct {
+	Items     []*Version `json:"items,omitempty"`      // 版本文档列表
+	PageToken *string    `json:"page_token,omitempty"` // 下页页码
+	HasMore   *bool      `json:"has_more,omitempty"`   // 是否有下一页数据
+}
+
+type ListFileVersionResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *ListFileVersionRespData `json:"data"` // 业务数据
+}
+
+func (resp *ListFileVersionResp) Success() bool {
+	return resp.Code == 0
+}
+
+type GetStatisticsFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewGetStatisticsFileReqBuilder() *GetStatisticsFileReqBuilder {
+	builder := &GetStatisticsFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件 token
+//
+// 示例值：doccnfYZzTlvXqZIGTdAHKabcef
+func (builder *GetStatisticsFileReqBuilder) FileToken(fileToken string) *GetStatisticsFileReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：bitable
+func (builder *GetStatisticsFileReqBuilder) FileType(fileType string) *GetStatisticsFileReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+func (builder *GetStatisticsFileReqBuilder) Build() *GetStatisticsFileReq {
+	req := &GetStatisticsFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type GetStatisticsFileReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type GetStatisticsFileRespData struct {
+	FileToken  *string         `json:"file_token,omitempty"` // 文档token
+	FileType   *string         `json:"file_type,omitempty"`  // 文档类型
+	Statistics *FileStatistics `json:"statistics,omitempty"` // 文档统计信息
+}
+
+type GetStatisticsFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *GetStatisticsFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *GetStatisticsFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type ListFileViewRecordReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	limit  int // 最大返回多少记录，当使用迭代器访问时才有效
+}
+
+func NewListFileViewRecordReqBuilder() *ListFileViewRecordReqBuilder {
+	builder := &ListFileViewRecordReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 最大返回多少记录，当使用迭代器访问时才有效
+func (builder *ListFileViewRecordReqBuilder) Limit(limit int) *ListFileViewRecordReqBuilder {
+	builder.limit = limit
+	return builder
+}
+
+// 文件 token
+//
+// 示例值：XIHSdYSI7oMEU1xrsnxc8fabcef
+func (builder *ListFileViewRecordReqBuilder) FileToken(fileToken string) *ListFileViewRecordReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 分页大小
+//
+// 示例值：10
+func (builder *ListFileViewRecordReqBuilder) PageSize(pageSize int) *ListFileViewRecordReqBuilder {
+	builder.apiReq.QueryParams.Set("page_size", fmt.Sprint(pageSize))
+	return builder
+}
+
+// 分页标记，第一次请求不填，表示从头开始遍历；分页查询结果还有更多项时会同时返回新的 page_token，下次遍历可采用该 page_token 获取查询结果
+//
+// 示例值：1674037112--7189934631754563585
+func (builder *ListFileViewRecordReqBuilder) PageToken(pageToken string) *ListFileViewRecordReqBuilder {
+	builder.apiReq.QueryParams.Set("page_token", fmt.Sprint(pageToken))
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：bitable
+func (builder *ListFileViewRecordReqBuilder) FileType(fileType string) *ListFileViewRecordReqBuilder {
+	builder.apiReq.QueryParams.Set("file_type", fmt.Sprint(fileType))
+	return builder
+}
+
+// 此次调用中使用的访问者 ID 的类型
+//
+// 示例值：open_id
+func (builder *ListFileViewRecordReqBuilder) ViewerIdType(viewerIdType string) *ListFileViewRecordReqBuilder {
+	builder.apiReq.QueryParams.Set("viewer_id_type", fmt.Sprint(viewerIdType))
+	return builder
+}
+
+func (builder *ListFileViewRecordReqBuilder) Build() *ListFileViewRecordReq {
+	req := &ListFileViewRecordReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.Limit = builder.limit
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type ListFileViewRecordReq struct {
+	apiReq *larkcore.ApiReq
+	Limit  int // 最多返回多少记录，只有在使用迭代器访问时，才有效
+
+}
+
+type ListFileViewRecordRespData struct {
+	Items     []*FileViewRecord `json:"items,omitempty"`      // 访问记录列表
+	PageToken *string           `json:"page_token,omitempty"` // 分页标记，当 has_more 为 true 时，会同时返回下一次遍历的page_token，否则则不返回
+	HasMore   *bool             `json:"has_more,omitempty"`   // 是否还有更多项
+}
+
+type ListFileViewRecordResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *ListFileViewRecordRespData `json:"data"` // 业务数据
+}
+
+func (resp *ListFileViewRecordResp) Success() bool {
+	return resp.Code == 0
+}
+
+type CreateFileSubscriptionReqBuilder struct {
+	apiReq           *larkcore.ApiReq
+	fileSubscription *FileSubscription
+}
+
+func NewCreateFileSubscriptionReqBuilder() *CreateFileSubscriptionReqBuilder {
+	builder := &CreateFileSubscriptionReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxcnxxxxxxxxxxxxxxxxxxxxxx
+func (builder *CreateFileSubscriptionReqBuilder) FileToken(fileToken string) *CreateFileSubscriptionReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 订阅文档中的变更事件，当前可订阅类型为评论更新事件
+func (builder *CreateFileSubscriptionReqBuilder) FileSubscription(fileSubscription *FileSubscription) *CreateFileSubscriptionReqBuilder {
+	builder.fileSubscription = fileSubscription
+	return builder
+}
+
+func (builder *CreateFileSubscriptionReqBuilder) Build() *CreateFileSubscriptionReq {
+	req := &CreateFileSubscriptionReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.Body = builder.fileSubscription
+	return req
+}
+
+type CreateFileSubscriptionReq struct {
+	apiReq           *larkcore.ApiReq
+	FileSubscription *FileSubscription `body:""`
+}
+
+type CreateFileSubscriptionRespData struct {
+	SubscriptionId   *string `json:"subscription_id,omitempty"`   // 订阅关系ID
+	SubscriptionType *string `json:"subscription_type,omitempty"` // 订阅类型
+	IsSubcribe       *bool   `json:"is_subcribe,omitempty"`       // 是否订阅
+	FileType         *string `json:"file_type,omitempty"`         // 文档类型
+}
+
+type CreateFileSubscriptionResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CreateFileSubscriptionRespData `json:"data"` // 业务数据
+}
+
+func (resp *CreateFileSubscriptionResp) Success() bool {
+	return resp.Code == 0
+}
+
+type GetFileSubscriptionReqBuilder struct {
+	apiReq           *larkcore.ApiReq
+	fileSubscription *FileSubscription
+}
+
+func NewGetFileSubscriptionReqBuilder() *GetFileSubscriptionReqBuilder {
+	builder := &GetFileSubscriptionReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxcnxxxxxxxxxxxxxxxxxxxxxx
+func (builder *GetFileSubscriptionReqBuilder) FileToken(fileToken string) *GetFileSubscriptionReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 订阅关系ID
+//
+// 示例值：1234567890987654321
+func (builder *GetFileSubscriptionReqBuilder) SubscriptionId(subscriptionId string) *GetFileSubscriptionReqBuilder {
+	builder.apiReq.PathParams.Set("subscription_id", fmt.Sprint(subscriptionId))
+	return builder
+}
+
+// 根据订阅ID获取该订阅的状态
+func (builder *GetFileSubscriptionReqBuilder) FileSubscription(fileSubscription *FileSubscription) *GetFileSubscriptionReqBuilder {
+	builder.fileSubscription = fileSubscription
+	return builder
+}
+
+func (builder *GetFileSubscriptionReqBuilder) Build() *GetFileSubscriptionReq {
+	req := &GetFileSubscriptionReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.Body = builder.fileSubscription
+	return req
+}
+
+type GetFileSubscriptionReq struct {
+	apiReq           *larkcore.ApiReq
+	FileSubscription *FileSubscription `body:""`
+}
+
+type GetFileSubscriptionRespData struct {
+	SubscriptionId   *string `json:"subscription_id,omitempty"`   // 订阅关系ID
+	SubscriptionType *string `json:"subscription_type,omitempty"` // 订阅类型
+	IsSubcribe       *bool   `json:"is_subcribe,omitempty"`       // 是否订阅
+	FileType         *string `json:"file_type,omitempty"`         // 文档类型
+}
+
+type GetFileSubscriptionResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *GetFileSubscriptionRespData `json:"data"` // 业务数据
+}
+
+func (resp *GetFileSubscriptionResp) Success() bool {
+	return resp.Code == 0
+}
+
+type PatchFileSubscriptionReqBodyBuilder struct {
+	isSubscribe     bool // 是否订阅
+	isSubscribeFlag bool
+	fileType        string // 文档类型
+	fileTypeFlag    bool
+}
+
+func NewPatchFileSubscriptionReqBodyBuilder() *PatchFileSubscriptionReqBodyBuilder {
+	builder := &PatchFileSubscriptionReqBodyBuilder{}
+	return builder
+}
+
+// 是否订阅
+//
+// 示例值：true
+func (builder *PatchFileSubscriptionReqBodyBuilder) IsSubscribe(isSubscribe bool) *PatchFileSubscriptionReqBodyBuilder {
+	builder.isSubscribe = isSubscribe
+	builder.isSubscribeFlag = true
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：bitable
+func (builder *PatchFileSubscriptionReqBodyBuilder) FileType(fileType string) *PatchFileSubscriptionReqBodyBuilder {
+	builder.fileType = fileType
+	builder.fileTypeFlag = true
+	return builder
+}
+
+func (builder *PatchFileSubscriptionReqBodyBuilder) Build() *PatchFileSubscriptionReqBody {
+	req := &PatchFileSubscriptionReqBody{}
+	if builder.isSubscribeFlag {
+		req.IsSubscribe = &builder.isSubscribe
+	}
+	if builder.fileTypeFlag {
+		req.FileType = &builder.fileType
+	}
+	return req
+}
+
+type PatchFileSubscriptionPathReqBodyBuilder struct {
+	isSubscribe     bool // 是否订阅
+	isSubscribeFlag bool
+	fileType        string // 文档类型
+	fileTypeFlag    bool
+}
+
+func NewPatchFileSubscriptionPathReqBodyBuilder() *PatchFileSubscriptionPathReqBodyBuilder {
+	builder := &PatchFileSubscriptionPathReqBodyBuilder{}
+	return builder
+}
+
+// 是否订阅
+//
+// 示例值：true
+func (builder *PatchFileSubscriptionPathReqBodyBuilder) IsSubscribe(isSubscribe bool) *PatchFileSubscriptionPathReqBodyBuilder {
+	builder.isSubscribe = isSubscribe
+	builder.isSubscribeFlag = true
+	return builder
+}
+
+// 文档类型
+//
+// 示例值：bitable
+func (builder *PatchFileSubscriptionPathReqBodyBuilder) FileType(fileType string) *PatchFileSubscriptionPathReqBodyBuilder {
+	builder.fileType = fileType
+	builder.fileTypeFlag = true
+	return builder
+}
+
+func (builder *PatchFileSubscriptionPathReqBodyBuilder) Build() (*PatchFileSubscriptionReqBody, error) {
+	req := &PatchFileSubscriptionReqBody{}
+	if builder.isSubscribeFlag {
+		req.IsSubscribe = &builder.isSubscribe
+	}
+	if builder.fileTypeFlag {
+		req.FileType = &builder.fileType
+	}
+	return req, nil
+}
+
+type PatchFileSubscriptionReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *PatchFileSubscriptionReqBody
+}
+
+func NewPatchFileSubscriptionReqBuilder() *PatchFileSubscriptionReqBuilder {
+	builder := &PatchFileSubscriptionReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文档token
+//
+// 示例值：doxcnxxxxxxxxxxxxxxxxxxxxxx
+func (builder *PatchFileSubscriptionReqBuilder) FileToken(fileToken string) *PatchFileSubscriptionReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 订阅关系ID
+//
+// 示例值：1234567890987654321
+func (builder *PatchFileSubscriptionReqBuilder) SubscriptionId(subscriptionId string) *PatchFileSubscriptionReqBuilder {
+	builder.apiReq.PathParams.Set("subscription_id", fmt.Sprint(subscriptionId))
+	return builder
+}
+
+// 根据订阅ID更新订阅状态
+func (builder *PatchFileSubscriptionReqBuilder) Body(body *PatchFileSubscriptionReqBody) *PatchFileSubscriptionReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *PatchFileSubscriptionReqBuilder) Build() *PatchFileSubscriptionReq {
+	req := &PatchFileSubscriptionReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type PatchFileSubscriptionReqBody struct {
+	IsSubscribe *bool   `json:"is_subscribe,omitempty"` // 是否订阅
+	FileType    *string `json:"file_type,omitempty"`    // 文档类型
+}
+
+type PatchFileSubscriptionReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *PatchFileSubscriptionReqBody `body:""`
+}
+
+type PatchFileSubscriptionRespData struct {
+	SubscriptionId   *string `json:"subscription_id,omitempty"`   // 订阅关系ID
+	SubscriptionType *string `json:"subscription_type,omitempty"` // 订阅类型
+	IsSubcribe       *bool   `json:"is_subcribe,omitempty"`       // 是否订阅
+	FileType         *string `json:"file_type,omitempty"`         // 文档类型
+}
+
+type PatchFileSubscriptionResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *PatchFileSubscriptionRespData `json:"data"` // 业务数据
+}
+
+func (resp *PatchFileSubscriptionResp) Success() bool {
+	return resp.Code == 0
+}
+
+type CopyFileReqBodyBuilder struct {
+	name            string // 被复制文件的新名称
+	nameFlag        bool
+	type_           string // 被复制文件的类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+	typeFlag        bool
+	folderToken     string // 文件被复制到的目标文件夹token
+	folderTokenFlag bool
+	extra           []*Property // 用户自定义请求附加参数，用于实现特殊的复制语义
+	extraFlag       bool
+}
+
+func NewCopyFileReqBodyBuilder() *CopyFileReqBodyBuilder {
+	builder := &CopyFileReqBodyBuilder{}
+	return builder
+}
+
+// 被复制文件的新名称
+//
+// 示例值：test.txt
+func (builder *CopyFileReqBodyBuilder) Name(name string) *CopyFileReqBodyBuilder {
+	builder.name = name
+	builder.nameFlag = true
+	return builder
+}
+
+// 被复制文件的类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+//
+// 示例值：bitable
+func (builder *CopyFileReqBodyBuilder) Type(type_ string) *CopyFileReqBodyBuilder {
+	builder.type_ = type_
+	builder.typeFlag = true
+	return builder
+}
+
+// 文件被复制到的目标文件夹token
+//
+// 示例值：fldbcO1UuPz8VwnpPx5a92abcef
+func (builder *CopyFileReqBodyBuilder) FolderToken(folderToken string) *CopyFileReqBodyBuilder {
+	builder.folderToken = folderToken
+	builder.folderTokenFlag = true
+	return builder
+}
+
+// 用户自定义请求附加参数，用于实现特殊的复制语义
+//
+// 示例值：
+func (builder *CopyFileReqBodyBuilder) Extra(extra []*Property) *CopyFileReqBodyBuilder {
+	builder.extra = extra
+	builder.extraFlag = true
+	return builder
+}
+
+func (builder *CopyFileReqBodyBuilder) Build() *CopyFileReqBody {
+	req := &CopyFileReqBody{}
+	if builder.nameFlag {
+		req.Name = &builder.name
+	}
+	if builder.typeFlag {
+		req.Type = &builder.type_
+	}
+	if builder.folderTokenFlag {
+		req.FolderToken = &builder.folderToken
+	}
+	if builder.extraFlag {
+		req.Extra = builder.extra
+	}
+	return req
+}
+
+type CopyFilePathReqBodyBuilder struct {
+	name            string // 被复制文件的新名称
+	nameFlag        bool
+	type_           string // 被复制文件的类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+	typeFlag        bool
+	folderToken     string // 文件被复制到的目标文件夹token
+	folderTokenFlag bool
+	extra           []*Property // 用户自定义请求附加参数，用于实现特殊的复制语义
+	extraFlag       bool
+}
+
+func NewCopyFilePathReqBodyBuilder() *CopyFilePathReqBodyBuilder {
+	builder := &CopyFilePathReqBodyBuilder{}
+	return builder
+}
+
+// 被复制文件的新名称
+//
+// 示例值：test.txt
+func (builder *CopyFilePathReqBodyBuilder) Name(name string) *CopyFilePathReqBodyBuilder {
+	builder.name = name
+	builder.nameFlag = true
+	return builder
+}
+
+// 被复制文件的类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+//
+// 示例值：bitable
+func (builder *CopyFilePathReqBodyBuilder) Type(type_ string) *CopyFilePathReqBodyBuilder {
+	builder.type_ = type_
+	builder.typeFlag = true
+	return builder
+}
+
+// 文件被复制到的目标文件夹token
+//
+// 示例值：fldbcO1UuPz8VwnpPx5a92abcef
+func (builder *CopyFilePathReqBodyBuilder) FolderToken(folderToken string) *CopyFilePathReqBodyBuilder {
+	builder.folderToken = folderToken
+	builder.folderTokenFlag = true
+	return builder
+}
+
+// 用户自定义请求附加参数，用于实现特殊的复制语义
+//
+// 示例值：
+func (builder *CopyFilePathReqBodyBuilder) Extra(extra []*Property) *CopyFilePathReqBodyBuilder {
+	builder.extra = extra
+	builder.extraFlag = true
+	return builder
+}
+
+func (builder *CopyFilePathReqBodyBuilder) Build() (*CopyFileReqBody, error) {
+	req := &CopyFileReqBody{}
+	if builder.nameFlag {
+		req.Name = &builder.name
+	}
+	if builder.typeFlag {
+		req.Type = &builder.type_
+	}
+	if builder.folderTokenFlag {
+		req.FolderToken = &builder.folderToken
+	}
+	if builder.extraFlag {
+		req.Extra = builder.extra
+	}
+	return req, nil
+}
+
+type CopyFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *CopyFileReqBody
+}
+
+func NewCopyFileReqBuilder() *CopyFileReqBuilder {
+	builder := &CopyFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 被操作的源文件的token
+//
+// 示例值：boxcnrHpsg1QDqXAAAyachabcef
+func (builder *CopyFileReqBuilder) FileToken(fileToken string) *CopyFileReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *CopyFileReqBuilder) UserIdType(userIdType string) *CopyFileReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+// 将文件复制到用户云空间的其他文件夹中。不支持复制文件夹。
+func (builder *CopyFileReqBuilder) Body(body *CopyFileReqBody) *CopyFileReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *CopyFileReqBuilder) Build() *CopyFileReq {
+	req := &CopyFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type CopyFileReqBody struct {
+	Name        *string     `json:"name,omitempty"`         // 被复制文件的新名称
+	Type        *string     `json:"type,omitempty"`         // 被复制文件的类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+	FolderToken *string     `json:"folder_token,omitempty"` // 文件被复制到的目标文件夹token
+	Extra       []*Property `json:"extra,omitempty"`        // 用户自定义请求附加参数，用于实现特殊的复制语义
+}
+
+type CopyFileReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *CopyFileReqBody `body:""`
+}
+
+type CopyFileRespData struct {
+	File *File `json:"file,omitempty"` // 复制后的文件资源
+}
+
+type CopyFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CopyFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *CopyFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type CreateFolderFileReqBodyBuilder struct {
+	name            string // 文件夹名称
+	nameFlag        bool
+	folderToken     string // 父文件夹token。如果需要创建到「我的空间」作为顶级文件夹，请传入我的空间token
+	folderTokenFlag bool
+}
+
+func NewCreateFolderFileReqBodyBuilder() *CreateFolderFileReqBodyBuilder {
+	builder := &CreateFolderFileReqBodyBuilder{}
+	return builder
+}
+
+// 文件夹名称
+//
+// 示例值：New Folder
+func (builder *CreateFolderFileReqBodyBuilder) Name(name string) *CreateFolderFileReqBodyBuilder {
+	builder.name = name
+	builder.nameFlag = true
+	return builder
+}
+
+// 父文件夹token。如果需要创建到「我的空间」作为顶级文件夹，请传入我的空间token
+//
+// 示例值：fldbcO1UuPz8VwnpPx5a92abcef
+func (builder *CreateFolderFileReqBodyBuilder) FolderToken(folderToken string) *CreateFolderFileReqBodyBuilder {
+	builder.folderToken = folderToken
+	builder.folderTokenFlag = true
+	return builder
+}
+
+func (builder *CreateFolderFileReqBodyBuilder) Build() *CreateFolderFileReqBody {
+	req := &CreateFolderFileReqBody{}
+	if builder.nameFlag {
+		req.Name = &builder.name
+	}
+	if builder.folderTokenFlag {
+		req.FolderToken = &builder.folderToken
+	}
+	return req
+}
+
+type CreateFolderFilePathReqBodyBuilder struct {
+	name            string // 文件夹名称
+	nameFlag        bool
+	folderToken     string // 父文件夹token。如果需要创建到「我的空间」作为顶级文件夹，请传入我的空间token
+	folderTokenFlag bool
+}
+
+func NewCreateFolderFilePathReqBodyBuilder() *CreateFolderFilePathReqBodyBuilder {
+	builder := &CreateFolderFilePathReqBodyBuilder{}
+	return builder
+}
+
+// 文件夹名称
+//
+// 示例值：New Folder
+func (builder *CreateFolderFilePathReqBodyBuilder) Name(name string) *CreateFolderFilePathReqBodyBuilder {
+	builder.name = name
+	builder.nameFlag = true
+	return builder
+}
+
+// 父文件夹token。如果需要创建到「我的空间」作为顶级文件夹，请传入我的空间token
+//
+// 示例值：fldbcO1UuPz8VwnpPx5a92abcef
+func (builder *CreateFolderFilePathReqBodyBuilder) FolderToken(folderToken string) *CreateFolderFilePathReqBodyBuilder {
+	builder.folderToken = folderToken
+	builder.folderTokenFlag = true
+	return builder
+}
+
+func (builder *CreateFolderFilePathReqBodyBuilder) Build() (*CreateFolderFileReqBody, error) {
+	req := &CreateFolderFileReqBody{}
+	if builder.nameFlag {
+		req.Name = &builder.name
+	}
+	if builder.folderTokenFlag {
+		req.FolderToken = &builder.folderToken
+	}
+	return req, nil
+}
+
+type CreateFolderFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *CreateFolderFileReqBody
+}
+
+func NewCreateFolderFileReqBuilder() *CreateFolderFileReqBuilder {
+	builder := &CreateFolderFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 该接口用于根据父文件夹的token在其中创建一个新的空文件夹。
+func (builder *CreateFolderFileReqBuilder) Body(body *CreateFolderFileReqBody) *CreateFolderFileReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *CreateFolderFileReqBuilder) Build() *CreateFolderFileReq {
+	req := &CreateFolderFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type CreateFolderFileReqBody struct {
+	Name        *string `json:"name,omitempty"`         // 文件夹名称
+	FolderToken *string `json:"folder_token,omitempty"` // 父文件夹token。如果需要创建到「我的空间」作为顶级文件夹，请传入我的空间token
+}
+
+type CreateFolderFileReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *CreateFolderFileReqBody `body:""`
+}
+
+type CreateFolderFileRespData struct {
+	Token *string `json:"token,omitempty"` // 新创建的文件夹 Token
+	Url   *string `json:"url,omitempty"`   // 创建文件夹的访问 URL
+}
+
+type CreateFolderFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CreateFolderFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *CreateFolderFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type CreateShortcutFileReqBodyBuilder struct {
+	parentToken     string // 创建快捷方式的目标父文件夹 token
+	parentTokenFlag bool
+	referEntity     *ReferEntity // 快捷方式映射到的文档和文件列表信息
+	referEntityFlag bool
+}
+
+func NewCreateShortcutFileReqBodyBuilder() *CreateShortcutFileReqBodyBuilder {
+	builder := &CreateShortcutFileReqBodyBuilder{}
+	return builder
+}
+
+// 创建快捷方式的目标父文件夹 token
+//
+// 示例值：fldbc5qgwyQnO0uedNllWuabcef
+func (builder *CreateShortcutFileReqBodyBuilder) ParentToken(parentToken string) *CreateShortcutFileReqBodyBuilder {
+	builder.parentToken = parentToken
+	builder.parentTokenFlag = true
+	return builder
+}
+
+// 快捷方式映射到的文档和文件列表信息
+//
+// 示例值：
+func (builder *CreateShortcutFileReqBodyBuilder) ReferEntity(referEntity *ReferEntity) *CreateShortcutFileReqBodyBuilder {
+	builder.referEntity = referEntity
+	builder.referEntityFlag = true
+	return builder
+}
+
+func (builder *CreateShortcutFileReqBodyBuilder) Build() *CreateShortcutFileReqBody {
+	req := &CreateShortcutFileReqBody{}
+	if builder.parentTokenFlag {
+		req.ParentToken = &builder.parentToken
+	}
+	if builder.referEntityFlag {
+		req.ReferEntity = builder.referEntity
+	}
+	return req
+}
+
+type CreateShortcutFilePathReqBodyBuilder struct {
+	parentToken     string // 创建快捷方式的目标父文件夹 token
+	parentTokenFlag bool
+	referEntity     *ReferEntity // 快捷方式映射到的文档和文件列表信息
+	referEntityFlag bool
+}
+
+func NewCreateShortcutFilePathReqBodyBuilder() *CreateShortcutFilePathReqBodyBuilder {
+	builder := &CreateShortcutFilePathReqBodyBuilder{}
+	return builder
+}
+
+// 创建快捷方式的目标父文件夹 token
+//
+// 示例值：fldbc5qgwyQnO0uedNllWuabcef
+func (builder *CreateShortcutFilePathReqBodyBuilder) ParentToken(parentToken string) *CreateShortcutFilePathReqBodyBuilder {
+	builder.parentToken = parentToken
+	builder.parentTokenFlag = true
+	return builder
+}
+
+// 快捷方式映射到的文档和文件列表信息
+//
+// 示例值：
+func (builder *CreateShortcutFilePathReqBodyBuilder) ReferEntity(referEntity *ReferEntity) *CreateShortcutFilePathReqBodyBuilder {
+	builder.referEntity = referEntity
+	builder.referEntityFlag = true
+	return builder
+}
+
+func (builder *CreateShortcutFilePathReqBodyBuilder) Build() (*CreateShortcutFileReqBody, error) {
+	req := &CreateShortcutFileReqBody{}
+	if builder.parentTokenFlag {
+		req.ParentToken = &builder.parentToken
+	}
+	if builder.referEntityFlag {
+		req.ReferEntity = builder.referEntity
+	}
+	return req, nil
+}
+
+type CreateShortcutFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *CreateShortcutFileReqBody
+}
+
+func NewCreateShortcutFileReqBuilder() *CreateShortcutFileReqBuilder {
+	builder := &CreateShortcutFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *CreateShortcutFileReqBuilder) UserIdType(userIdType string) *CreateShortcutFileReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+// 创建指定文件的快捷方式到云空间的其他文件夹中。
+func (builder *CreateShortcutFileReqBuilder) Body(body *CreateShortcutFileReqBody) *CreateShortcutFileReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *CreateShortcutFileReqBuilder) Build() *CreateShortcutFileReq {
+	req := &CreateShortcutFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type CreateShortcutFileReqBody struct {
+	ParentToken *string      `json:"parent_token,omitempty"` // 创建快捷方式的目标父文件夹 token
+	ReferEntity *ReferEntity `json:"refer_entity,omitempty"` // 快捷方式映射到的文档和文件列表信息
+}
+
+type CreateShortcutFileReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *CreateShortcutFileReqBody `body:""`
+}
+
+type CreateShortcutFileRespData struct {
+	SuccShortcutNode *File `json:"succ_shortcut_node,omitempty"` // 返回创建成功的shortcut节点
+}
+
+type CreateShortcutFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *CreateShortcutFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *CreateShortcutFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type DeleteFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewDeleteFileReqBuilder() *DeleteFileReqBuilder {
+	builder := &DeleteFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 需要删除的文件token
+//
+// 示例值：boxcnrHpsg1QDqXAAAyachabcef
+func (builder *DeleteFileReqBuilder) FileToken(fileToken string) *DeleteFileReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 被删除文件的类型
+//
+// 示例值：bitable
+func (builder *DeleteFileReqBuilder) Type(type_ string) *DeleteFileReqBuilder {
+	builder.apiReq.QueryParams.Set("type", fmt.Sprint(type_))
+	return builder
+}
+
+func (builder *DeleteFileReqBuilder) Build() *DeleteFileReq {
+	req := &DeleteFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type DeleteFileReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type DeleteFileRespData struct {
+	TaskId *string `json:"task_id,omitempty"` // 异步任务id，删除文件夹时返回
+}
+
+type DeleteFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *DeleteFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *DeleteFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type ListFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	limit  int // 最大返回多少记录，当使用迭代器访问时才有效
+}
+
+func NewListFileReqBuilder() *ListFileReqBuilder {
+	builder := &ListFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 最大返回多少记录，当使用迭代器访问时才有效
+func (builder *ListFileReqBuilder) Limit(limit int) *ListFileReqBuilder {
+	builder.limit = limit
+	return builder
+}
+
+// 分页大小
+//
+// 示例值：50
+func (builder *ListFileReqBuilder) PageSize(pageSize int) *ListFileReqBuilder {
+	builder.apiReq.QueryParams.Set("page_size", fmt.Sprint(pageSize))
+	return builder
+}
+
+// 分页标记，第一次请求不填，表示从头开始遍历；分页查询结果还有更多项时会同时返回新的 page_token，下次遍历可采用该 page_token 获取查询结果
+//
+// 示例值：MTY1NTA3MTA1OXw3MTA4NDc3MDg1NzcwNjM5NTIy
+func (builder *ListFileReqBuilder) PageToken(pageToken string) *ListFileReqBuilder {
+	builder.apiReq.QueryParams.Set("page_token", fmt.Sprint(pageToken))
+	return builder
+}
+
+// 文件夹的token（若不填写该参数或填写空字符串，则默认获取用户云空间下的清单，且不支持分页）
+//
+// 示例值：fldbcO1UuPz8VwnpPx5a9abcef
+func (builder *ListFileReqBuilder) FolderToken(folderToken string) *ListFileReqBuilder {
+	builder.apiReq.QueryParams.Set("folder_token", fmt.Sprint(folderToken))
+	return builder
+}
+
+// 定义清单中文件的排序方式
+//
+// 示例值：EditedTime
+func (builder *ListFileReqBuilder) OrderBy(orderBy string) *ListFileReqBuilder {
+	builder.apiReq.QueryParams.Set("order_by", fmt.Sprint(orderBy))
+	return builder
+}
+
+// 定义清单中文件的排序规则
+//
+// 示例值：DESC
+func (builder *ListFileReqBuilder) Direction(direction string) *ListFileReqBuilder {
+	builder.apiReq.QueryParams.Set("direction", fmt.Sprint(direction))
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *ListFileReqBuilder) UserIdType(userIdType string) *ListFileReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+func (builder *ListFileReqBuilder) Build() *ListFileReq {
+	req := &ListFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.Limit = builder.limit
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type ListFileReq struct {
+	apiReq *larkcore.ApiReq
+	Limit  int // 最多返回多少记录，只有在使用迭代器访问时，才有效
+
+}
+
+type ListFileRespData struct {
+	Files         []*File `json:"files,omitempty"`           // 文件夹清单列表
+	NextPageToken *string `json:"next_page_token,omitempty"` // 分页标记，当 has_more 为 true 时，会同时返回下一次遍历的page_token，否则则不返回
+	HasMore       *bool   `json:"has_more,omitempty"`        // 是否还有更多项
+}
+
+type ListFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *ListFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *ListFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type MoveFileReqBodyBuilder struct {
+	type_           string // 文件类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+	typeFlag        bool
+	folderToken     string // 目标文件夹token
+	folderTokenFlag bool
+}
+
+func NewMoveFileReqBodyBuilder() *MoveFileReqBodyBuilder {
+	builder := &MoveFileReqBodyBuilder{}
+	return builder
+}
+
+// 文件类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+//
+// 示例值：bitable
+func (builder *MoveFileReqBodyBuilder) Type(type_ string) *MoveFileReqBodyBuilder {
+	builder.type_ = type_
+	builder.typeFlag = true
+	return builder
+}
+
+// 目标文件夹token
+//
+// 示例值：fldbcO1UuPz8VwnpPx5a92abcef
+func (builder *MoveFileReqBodyBuilder) FolderToken(folderToken string) *MoveFileReqBodyBuilder {
+	builder.folderToken = folderToken
+	builder.folderTokenFlag = true
+	return builder
+}
+
+func (builder *MoveFileReqBodyBuilder) Build() *MoveFileReqBody {
+	req := &MoveFileReqBody{}
+	if builder.typeFlag {
+		req.Type = &builder.type_
+	}
+	if builder.folderTokenFlag {
+		req.FolderToken = &builder.folderToken
+	}
+	return req
+}
+
+type MoveFilePathReqBodyBuilder struct {
+	type_           string // 文件类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+	typeFlag        bool
+	folderToken     string // 目标文件夹token
+	folderTokenFlag bool
+}
+
+func NewMoveFilePathReqBodyBuilder() *MoveFilePathReqBodyBuilder {
+	builder := &MoveFilePathReqBodyBuilder{}
+	return builder
+}
+
+// 文件类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+//
+// 示例值：bitable
+func (builder *MoveFilePathReqBodyBuilder) Type(type_ string) *MoveFilePathReqBodyBuilder {
+	builder.type_ = type_
+	builder.typeFlag = true
+	return builder
+}
+
+// 目标文件夹token
+//
+// 示例值：fldbcO1UuPz8VwnpPx5a92abcef
+func (builder *MoveFilePathReqBodyBuilder) FolderToken(folderToken string) *MoveFilePathReqBodyBuilder {
+	builder.folderToken = folderToken
+	builder.folderTokenFlag = true
+	return builder
+}
+
+func (builder *MoveFilePathReqBodyBuilder) Build() (*MoveFileReqBody, error) {
+	req := &MoveFileReqBody{}
+	if builder.typeFlag {
+		req.Type = &builder.type_
+	}
+	if builder.folderTokenFlag {
+		req.FolderToken = &builder.folderToken
+	}
+	return req, nil
+}
+
+type MoveFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *MoveFileReqBody
+}
+
+func NewMoveFileReqBuilder() *MoveFileReqBuilder {
+	builder := &MoveFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 需要移动的文件token
+//
+// 示例值：boxcnrHpsg1QDqXAAAyachabcef
+func (builder *MoveFileReqBuilder) FileToken(fileToken string) *MoveFileReqBuilder {
+	builder.apiReq.PathParams.Set("file_token", fmt.Sprint(fileToken))
+	return builder
+}
+
+// 将文件或者文件夹移动到用户云空间的其他位置。
+func (builder *MoveFileReqBuilder) Body(body *MoveFileReqBody) *MoveFileReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *MoveFileReqBuilder) Build() *MoveFileReq {
+	req := &MoveFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.PathParams = builder.apiReq.PathParams
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type MoveFileReqBody struct {
+	Type        *string `json:"type,omitempty"`         // 文件类型，如果该值为空或者与文件实际类型不匹配，接口会返回失败。
+	FolderToken *string `json:"folder_token,omitempty"` // 目标文件夹token
+}
+
+type MoveFileReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *MoveFileReqBody `body:""`
+}
+
+type MoveFileRespData struct {
+	TaskId *string `json:"task_id,omitempty"` // 异步任务id，移动文件夹时返回
+}
+
+type MoveFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *MoveFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *MoveFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type TaskCheckFileReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewTaskCheckFileReqBuilder() *TaskCheckFileReqBuilder {
+	builder := &TaskCheckFileReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件相关异步任务id
+//
+// 示例值：12345
+func (builder *TaskCheckFileReqBuilder) TaskId(taskId string) *TaskCheckFileReqBuilder {
+	builder.apiReq.QueryParams.Set("task_id", fmt.Sprint(taskId))
+	return builder
+}
+
+func (builder *TaskCheckFileReqBuilder) Build() *TaskCheckFileReq {
+	req := &TaskCheckFileReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type TaskCheckFileReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type TaskCheckFileRespData struct {
+	Status *string `json:"status,omitempty"` // 异步任务的执行状态，如果任务执行成功则返回success，如果任务执行失败则返回fail，如果任务还在执行中则返回process。
+}
+
+type TaskCheckFileResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *TaskCheckFileRespData `json:"data"` // 业务数据
+}
+
+func (resp *TaskCheckFileResp) Success() bool {
+	return resp.Code == 0
+}
+
+type BatchQueryMetaReqBuilder struct {
+	apiReq      *larkcore.ApiReq
+	metaRequest *MetaRequest
+}
+
+func NewBatchQueryMetaReqBuilder() *BatchQueryMetaReqBuilder {
+	builder := &BatchQueryMetaReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 此次调用中使用的用户ID的类型
+//
+// 示例值：open_id
+func (builder *BatchQueryMetaReqBuilder) UserIdType(userIdType string) *BatchQueryMetaReqBuilder {
+	builder.apiReq.QueryParams.Set("user_id_type", fmt.Sprint(userIdType))
+	return builder
+}
+
+// 该接口用于根据 token 获取各类文件的元数据
+func (builder *BatchQueryMetaReqBuilder) MetaRequest(metaRequest *MetaRequest) *BatchQueryMetaReqBuilder {
+	builder.metaRequest = metaRequest
+	return builder
+}
+
+func (builder *BatchQueryMetaReqBuilder) Build() *BatchQueryMetaReq {
+	req := &BatchQueryMetaReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	req.apiReq.Body = builder.metaRequest
+	return req
+}
+
+type BatchQueryMetaReq struct {
+	apiReq      *larkcore.ApiReq
+	MetaRequest *MetaRequest `body:""`
+}
+
+type BatchQueryMetaRespData struct {
+	Metas      []*Meta       `json:"metas,omitempty"`       // 文件元数据
+	FailedList []*MetaFailed `json:"failed_list,omitempty"` // 无法获取元数据的文档
+}
+
+type BatchQueryMetaResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *BatchQueryMetaRespData `json:"data"` // 业务数据
+}
+
+func (resp *BatchQueryMetaResp) Success() bool {
+	return resp.Code == 0
+}
+
+type BatchGetTmpDownloadUrlMediaReqBuilder struct {
+	apiReq *larkcore.ApiReq
+}
+
+func NewBatchGetTmpDownloadUrlMediaReqBuilder() *BatchGetTmpDownloadUrlMediaReqBuilder {
+	builder := &BatchGetTmpDownloadUrlMediaReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 文件标识符列表
+//
+// 示例值：boxcnrHpsg1QDqXAAAyachabcef
+func (builder *BatchGetTmpDownloadUrlMediaReqBuilder) FileTokens(fileTokens []string) *BatchGetTmpDownloadUrlMediaReqBuilder {
+	for _, v := range fileTokens {
+		builder.apiReq.QueryParams.Add("file_tokens", fmt.Sprint(v))
+	}
+	return builder
+}
+
+// 拓展信息(可选)
+//
+// 示例值：[请参考-上传点类型及对应Extra说明](https://open.feishu.cn/document/uAjLw4CM/ukTMukTMukTM/reference/drive-v1/media/introduction)
+func (builder *BatchGetTmpDownloadUrlMediaReqBuilder) Extra(extra string) *BatchGetTmpDownloadUrlMediaReqBuilder {
+	builder.apiReq.QueryParams.Set("extra", fmt.Sprint(extra))
+	return builder
+}
+
+func (builder *BatchGetTmpDownloadUrlMediaReqBuilder) Build() *BatchGetTmpDownloadUrlMediaReq {
+	req := &BatchGetTmpDownloadUrlMediaReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.QueryParams = builder.apiReq.QueryParams
+	return req
+}
+
+type BatchGetTmpDownloadUrlMediaReq struct {
+	apiReq *larkcore.ApiReq
+}
+
+type BatchGetTmpDownloadUrlMediaRespData struct {
+	TmpDownloadUrls []*TmpDownloadUrl `json:"tmp_download_urls,omitempty"` // 临时下载列表
+}
+
+type BatchGetTmpDownloadUrlMediaResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *BatchGetTmpDownloadUrlMediaRespData `json:"data"` // 业务数据
+}
+
+func (resp *BatchGetTmpDownloadUrlMediaResp) Success() bool {
+	return resp.Code == 0
+}
+
+type UploadFinishMediaReqBodyBuilder struct {
+	uploadId     string // 分片上传事务ID
+	uploadIdFlag bool
+	blockNum     int // 分片数量
+	blockNumFlag bool
+}
+
+func NewUploadFinishMediaReqBodyBuilder() *UploadFinishMediaReqBodyBuilder {
+	builder := &UploadFinishMediaReqBodyBuilder{}
+	return builder
+}
+
+// 分片上传事务ID
+//
+// 示例值：7111211691345512356
+func (builder *UploadFinishMediaReqBodyBuilder) UploadId(uploadId string) *UploadFinishMediaReqBodyBuilder {
+	builder.uploadId = uploadId
+	builder.uploadIdFlag = true
+	return builder
+}
+
+// 分片数量
+//
+// 示例值：1
+func (builder *UploadFinishMediaReqBodyBuilder) BlockNum(blockNum int) *UploadFinishMediaReqBodyBuilder {
+	builder.blockNum = blockNum
+	builder.blockNumFlag = true
+	return builder
+}
+
+func (builder *UploadFinishMediaReqBodyBuilder) Build() *UploadFinishMediaReqBody {
+	req := &UploadFinishMediaReqBody{}
+	if builder.uploadIdFlag {
+		req.UploadId = &builder.uploadId
+	}
+	if builder.blockNumFlag {
+		req.BlockNum = &builder.blockNum
+	}
+	return req
+}
+
+type UploadFinishMediaPathReqBodyBuilder struct {
+	uploadId     string // 分片上传事务ID
+	uploadIdFlag bool
+	blockNum     int // 分片数量
+	blockNumFlag bool
+}
+
+func NewUploadFinishMediaPathReqBodyBuilder() *UploadFinishMediaPathReqBodyBuilder {
+	builder := &UploadFinishMediaPathReqBodyBuilder{}
+	return builder
+}
+
+// 分片上传事务ID
+//
+// 示例值：7111211691345512356
+func (builder *UploadFinishMediaPathReqBodyBuilder) UploadId(uploadId string) *UploadFinishMediaPathReqBodyBuilder {
+	builder.uploadId = uploadId
+	builder.uploadIdFlag = true
+	return builder
+}
+
+// 分片数量
+//
+// 示例值：1
+func (builder *UploadFinishMediaPathReqBodyBuilder) BlockNum(blockNum int) *UploadFinishMediaPathReqBodyBuilder {
+	builder.blockNum = blockNum
+	builder.blockNumFlag = true
+	return builder
+}
+
+func (builder *UploadFinishMediaPathReqBodyBuilder) Build() (*UploadFinishMediaReqBody, error) {
+	req := &UploadFinishMediaReqBody{}
+	if builder.uploadIdFlag {
+		req.UploadId = &builder.uploadId
+	}
+	if builder.blockNumFlag {
+		req.BlockNum = &builder.blockNum
+	}
+	return req, nil
+}
+
+type UploadFinishMediaReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *UploadFinishMediaReqBody
+}
+
+func NewUploadFinishMediaReqBuilder() *UploadFinishMediaReqBuilder {
+	builder := &UploadFinishMediaReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 触发完成上传。
+func (builder *UploadFinishMediaReqBuilder) Body(body *UploadFinishMediaReqBody) *UploadFinishMediaReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *UploadFinishMediaReqBuilder) Build() *UploadFinishMediaReq {
+	req := &UploadFinishMediaReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type UploadFinishMediaReqBody struct {
+	UploadId *string `json:"upload_id,omitempty"` // 分片上传事务ID
+	BlockNum *int    `json:"block_num,omitempty"` // 分片数量
+}
+
+type UploadFinishMediaReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *UploadFinishMediaReqBody `body:""`
+}
+
+type UploadFinishMediaRespData struct {
+	FileToken *string `json:"file_token,omitempty"` // 新创建文件的 token
+}
+
+type UploadFinishMediaResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *UploadFinishMediaRespData `json:"data"` // 业务数据
+}
+
+func (resp *UploadFinishMediaResp) Success() bool {
+	return resp.Code == 0
+}
+
+type UploadPartMediaReqBodyBuilder struct {
+	uploadId     string // 分片上传事务ID。
+	uploadIdFlag bool
+	seq          int // 块号，从0开始计数。
+	seqFlag      bool
+	size         int // 块大小（以字节为单位）。
+	sizeFlag     bool
+	checksum     string // 文件分块adler32校验和(可选)。
+	checksumFlag bool
+	file         io.Reader // 文件分片二进制内容。
+	fileFlag     bool
+}
+
+func NewUploadPartMediaReqBodyBuilder() *UploadPartMediaReqBodyBuilder {
+	builder := &UploadPartMediaReqBodyBuilder{}
+	return builder
+}
+
+// 分片上传事务ID。
+//
+// 示例值：7111211691345512356
+func (builder *UploadPartMediaReqBodyBuilder) UploadId(uploadId string) *UploadPartMediaReqBodyBuilder {
+	builder.uploadId = uploadId
+	builder.uploadIdFlag = true
+	return builder
+}
+
+// 块号，从0开始计数。
+//
+// 示例值：0
+func (builder *UploadPartMediaReqBodyBuilder) Seq(seq int) *UploadPartMediaReqBodyBuilder {
+	builder.seq = seq
+	builder.seqFlag = true
+	return builder
+}
+
+// 块大小（以字节为单位）。
+//
+// 示例值：4194304
+func (builder *UploadPartMediaReqBodyBuilder) Size(size int) *UploadPartMediaReqBodyBuilder {
+	builder.size = size
+	builder.sizeFlag = true
+	return builder
+}
+
+// 文件分块adler32校验和(可选)。
+//
+// 示例值：3248270248
+func (builder *UploadPartMediaReqBodyBuilder) Checksum(checksum string) *UploadPartMediaReqBodyBuilder {
+	builder.checksum = checksum
+	builder.checksumFlag = true
+	return builder
+}
+
+// 文件分片二进制内容。
+//
+// 示例值：file binary
+func (builder *UploadPartMediaReqBodyBuilder) File(file io.Reader) *UploadPartMediaReqBodyBuilder {
+	builder.file = file
+	builder.fileFlag = true
+	return builder
+}
+
+func (builder *UploadPartMediaReqBodyBuilder) Build() *UploadPartMediaReqBody {
+	req := &UploadPartMediaReqBody{}
+	if builder.uploadIdFlag {
+		req.UploadId = &builder.uploadId
+	}
+	if builder.seqFlag {
+		req.Seq = &builder.seq
+	}
+	if builder.sizeFlag {
+		req.Size = &builder.size
+	}
+	if builder.checksumFlag {
+		req.Checksum = &builder.checksum
+	}
+	if builder.fileFlag {
+		req.File = builder.file
+	}
+	return req
+}
+
+type UploadPartMediaPathReqBodyBuilder struct {
+	uploadId     string // 分片上传事务ID。
+	uploadIdFlag bool
+	seq          int // 块号，从0开始计数。
+	seqFlag      bool
+	size         int // 块大小（以字节为单位）。
+	sizeFlag     bool
+	checksum     string // 文件分块adler32校验和(可选)。
+	checksumFlag bool
+	filePath     string // 文件分片二进制内容。
+	filePathFlag bool
+}
+
+func NewUploadPartMediaPathReqBodyBuilder() *UploadPartMediaPathReqBodyBuilder {
+	builder := &UploadPartMediaPathReqBodyBuilder{}
+	return builder
+}
+
+// 分片上传事务ID。
+//
+// 示例值：7111211691345512356
+func (builder *UploadPartMediaPathReqBodyBuilder) UploadId(uploadId string) *UploadPartMediaPathReqBodyBuilder {
+	builder.uploadId = uploadId
+	builder.uploadIdFlag = true
+	return builder
+}
+
+// 块号，从0开始计数。
+//
+// 示例值：0
+func (builder *UploadPartMediaPathReqBodyBuilder) Seq(seq int) *UploadPartMediaPathReqBodyBuilder {
+	builder.seq = seq
+	builder.seqFlag = true
+	return builder
+}
+
+// 块大小（以字节为单位）。
+//
+// 示例值：4194304
+func (builder *UploadPartMediaPathReqBodyBuilder) Size(size int) *UploadPartMediaPathReqBodyBuilder {
+	builder.size = size
+	builder.sizeFlag = true
+	return builder
+}
+
+// 文件分块adler32校验和(可选)。
+//
+// 示例值：3248270248
+func (builder *UploadPartMediaPathReqBodyBuilder) Checksum(checksum string) *UploadPartMediaPathReqBodyBuilder {
+	builder.checksum = checksum
+	builder.checksumFlag = true
+	return builder
+}
+
+// 文件分片二进制内容。
+//
+// 示例值：file binary
+func (builder *UploadPartMediaPathReqBodyBuilder) FilePath(filePath string) *UploadPartMediaPathReqBodyBuilder {
+	builder.filePath = filePath
+	builder.filePathFlag = true
+	return builder
+}
+
+func (builder *UploadPartMediaPathReqBodyBuilder) Build() (*UploadPartMediaReqBody, error) {
+	req := &UploadPartMediaReqBody{}
+	if builder.uploadIdFlag {
+		req.UploadId = &builder.uploadId
+	}
+	if builder.seqFlag {
+		req.Seq = &builder.seq
+	}
+	if builder.sizeFlag {
+		req.Size = &builder.size
+	}
+	if builder.checksumFlag {
+		req.Checksum = &builder.checksum
+	}
+	if builder.filePathFlag {
+		data, err := larkcore.File2Bytes(builder.filePath)
+		if err != nil {
+			return nil, err
+		}
+		req.File = bytes.NewBuffer(data)
+	}
+	return req, nil
+}
+
+type UploadPartMediaReqBuilder struct {
+	apiReq *larkcore.ApiReq
+	body   *UploadPartMediaReqBody
+}
+
+func NewUploadPartMediaReqBuilder() *UploadPartMediaReqBuilder {
+	builder := &UploadPartMediaReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 上传对应的文件块。
+func (builder *UploadPartMediaReqBuilder) Body(body *UploadPartMediaReqBody) *UploadPartMediaReqBuilder {
+	builder.body = body
+	return builder
+}
+
+func (builder *UploadPartMediaReqBuilder) Build() *UploadPartMediaReq {
+	req := &UploadPartMediaReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.Body = builder.body
+	return req
+}
+
+type UploadPartMediaReqBody struct {
+	UploadId *string   `json:"upload_id,omitempty"` // 分片上传事务ID。
+	Seq      *int      `json:"seq,omitempty"`       // 块号，从0开始计数。
+	Size     *int      `json:"size,omitempty"`      // 块大小（以字节为单位）。
+	Checksum *string   `json:"checksum,omitempty"`  // 文件分块adler32校验和(可选)。
+	File     io.Reader `json:"file,omitempty"`      // 文件分片二进制内容。
+}
+
+type UploadPartMediaReq struct {
+	apiReq *larkcore.ApiReq
+	Body   *UploadPartMediaReqBody `body:""`
+}
+
+type UploadPartMediaResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+}
+
+func (resp *UploadPartMediaResp) Success() bool {
+	return resp.Code == 0
+}
+
+type UploadPrepareMediaReqBuilder struct {
+	apiReq          *larkcore.ApiReq
+	mediaUploadInfo *MediaUploadInfo
+}
+
+func NewUploadPrepareMediaReqBuilder() *UploadPrepareMediaReqBuilder {
+	builder := &UploadPrepareMediaReqBuilder{}
+	builder.apiReq = &larkcore.ApiReq{
+		PathParams:  larkcore.PathParams{},
+		QueryParams: larkcore.QueryParams{},
+	}
+	return builder
+}
+
+// 发送初始化请求获取上传事务ID和分块策略，目前是以4MB大小进行定长分片。
+func (builder *UploadPrepareMediaReqBuilder) MediaUploadInfo(mediaUploadInfo *MediaUploadInfo) *UploadPrepareMediaReqBuilder {
+	builder.mediaUploadInfo = mediaUploadInfo
+	return builder
+}
+
+func (builder *UploadPrepareMediaReqBuilder) Build() *UploadPrepareMediaReq {
+	req := &UploadPrepareMediaReq{}
+	req.apiReq = &larkcore.ApiReq{}
+	req.apiReq.Body = builder.mediaUploadInfo
+	return req
+}
+
+type UploadPrepareMediaReq struct {
+	apiReq          *larkcore.ApiReq
+	MediaUploadInfo *MediaUploadInfo `body:""`
+}
+
+type UploadPrepareMediaRespData struct {
+	UploadId  *string `json:"upload_id,omitempty"`  // 分片上传事务ID
+	BlockSize *int    `json:"block_size,omitempty"` // 分片大小策略
+	BlockNum  *int    `json:"block_num,omitempty"`  // 分片数量
+}
+
+type UploadPrepareMediaResp struct {
+	*larkcore.ApiResp `json:"-"`
+	larkcore.CodeError
+	Data *UploadPrepareMediaRespData `json:"data"` // 业务数据
+}
+
+func (resp *UploadPrepareMediaResp) Success() bool {
+	return resp.Code == 0
+}
+
+type ListFileCommentIterator struct {
+	nextPageToken *string
+	items         []*FileComment
+	index         int
+	limit         int
+	ctx           context.Context
+	req           *ListFileCommentReq
+	listFunc      func(ctx context.Context, req *ListFileCommentReq, options ...larkcore.RequestOptionFunc) (*ListFileCommentResp, error)
+	options       []larkcore.RequestOptionFunc
+	curlNum       int
+}
+
+func (iterator *ListFileCommentIterator) Next() (bool, *FileComment, error) {
+	// 达到最大量，则返回
+	if iterator.limit > 0 && iterator.curlNum >= iterator.limit {
+		return false, nil, nil
+	}
+
+	// 为0则拉取数据
+	if iterator.index == 0 || iterator.index >= len(iterator.items) {
+		if iterator.index != 0 && iterator.nextPageToken == nil {
+			return false, nil, nil
+		}
+		if iterator.nextPageToken != nil {
+			iterator.req.apiReq.QueryParams.Set("page_token", *iterator.nextPageToken)
+		}
+		resp, err := iterator.listFunc(iterator.ctx, iterator.req, iterator.options...)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if resp.Code != 0 {
+			return false, nil, errors.New(fmt.Sprintf("Code:%d,Msg:%s", resp.Code, resp.Msg))
+		}
+
+		if len(resp.Data.Items) == 0 {
+			return false, nil, nil
+		}
+
+		iterator.nextPageToken = resp.Data.PageToken
+		iterator.items = resp.Data.Items
+		iterator.index = 0
+	}
+
+	block := iterator.items[iterator.index]
+	iterator.index++
+	iterator.curlNum++
+	return true, block, nil
+}
+
+func (iterator *ListFileCommentIterator) NextPageToken() *string {
+	return iterator.nextPageToken
+}
+
+type ListFileCommentReplyIterator struct {
+	nextPageToken *string
+	items         []*FileCommentReply
+	index         int
+	limit         int
+	ctx           context.Context
+	req           *ListFileCommentReplyReq
+	listFunc      func(ctx context.Context, req *ListFileCommentReplyReq, options ...larkcore.RequestOptionFunc) (*ListFileCommentReplyResp, error)
+	options       []larkcore.RequestOptionFunc
+	curlNum       int
+}
+
+func (iterator *ListFileCommentReplyIterator) Next() (bool, *FileCommentReply, error) {
+	// 达到最大量，则返回
+	if iterator.limit > 0 && iterator.curlNum >= iterator.limit {
+		return false, nil, nil
+	}
+
+	// 为0则拉取数据
+	if iterator.index == 0 || iterator.index >= len(iterator.items) {
+		if iterator.index != 0 && iterator.nextPageToken == nil {
+			return false, nil, nil
+		}
+		if iterator.nextPageToken != nil {
+			iterator.req.apiReq.QueryParams.Set("page_token", *iterator.nextPageToken)
+		}
+		resp, err := iterator.listFunc(iterator.ctx, iterator.req, iterator.options...)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if resp.Code != 0 {
+			return false, nil, errors.New(fmt.Sprintf("Code:%d,Msg:%s", resp.Code, resp.Msg))
+		}
+
+		if len(resp.Data.Items) == 0 {
+			return false, nil, nil
+		}
+
+		iterator.nextPageToken = resp.Data.PageToken
+		iterator.items = resp.Data.Items
+		iterator.index = 0
+	}
+
+	block := iterator.items[iterator.index]
+	iterator.index++
+	iterator.curlNum++
+	return true, block, nil
+}
+
+func (iterator *ListFileCommentReplyIterator) NextPageToken() *string {
+	return iterator.nextPageToken
+}
+
+type ListFileVersionIterator struct {
+	nextPageToken *string
+	items         []*Version
+	index         int
+	limit         int
+	ctx           context.Context
+	req           *ListFileVersionReq
+	listFunc      func(ctx context.Context, req *ListFileVersionReq, options ...larkcore.RequestOptionFunc) (*ListFileVersionResp, error)
+	options       []larkcore.RequestOptionFunc
+	curlNum       int
+}
+
+func (iterator *ListFileVersionIterator) Next() (bool, *Version, error) {
+	// 达到最大量，则返回
+	if iterator.limit > 0 && iterator.curlNum >= iterator.limit {
+		return false, nil, nil
+	}
+
+	// 为0则拉取数据
+	if iterator.index == 0 || iterator.index >= len(iterator.items) {
+		if iterator.index != 0 && iterator.nextPageToken == nil {
+			return false, nil, nil
+		}
+		if iterator.nextPageToken != nil {
+			iterator.req.apiReq.QueryParams.Set("page_token", *iterator.nextPageToken)
+		}
+		resp, err := iterator.listFunc(iterator.ctx, iterator.req, iterator.options...)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if resp.Code != 0 {
+			return false, nil, errors.New(fmt.Sprintf("Code:%d,Msg:%s", resp.Code, resp.Msg))
+		}
+
+		if len(resp.Data.Items) == 0 {
+			return false, nil, nil
+		}
+
+		iterator.nextPageToken = resp.Data.PageToken
+		iterator.items = resp.Data.Items
+		iterator.index = 0
+	}
+
+	block := iterator.items[iterator.index]
+	iterator.index++
+	iterator.curlNum++
+	return true, block, nil
+}
+
+func (iterator *ListFileVersionIterator) NextPageToken() *string {
+	return iterator.nextPageToken
+}
+
+type ListFileViewRecordIterator struct {
+	nextPageToken *string
+	items         []*FileViewRecord
+	index         int
+	limit         int
+	ctx           context.Context
+	req           *ListFileViewRecordReq
+	listFunc      func(ctx context.Context, req *ListFileViewRecordReq, options ...larkcore.RequestOptionFunc) (*ListFileViewRecordResp, error)
+	options       []larkcore.RequestOptionFunc
+	curlNum       int
+}
+
+func (iterator *ListFileViewRecordIterator) Next() (bool, *FileViewRecord, error) {
+	// 达到最大量，则返回
+	if iterator.limit > 0 && iterator.curlNum >= iterator.limit {
+		return false, nil, nil
+	}
+
+	// 为0则拉取数据
+	if iterator.index == 0 || iterator.index >= len(iterator.items) {
+		if iterator.index != 0 && iterator.nextPageToken == nil {
+			return false, nil, nil
+		}
+		if iterator.nextPageToken != nil {
+			iterator.req.apiReq.QueryParams.Set("page_token", *iterator.nextPageToken)
+		}
+		resp, err := iterator.listFunc(iterator.ctx, iterator.req, iterator.options...)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if resp.Code != 0 {
+			return false, nil, errors.New(fmt.Sprintf("Code:%d,Msg:%s", resp.Code, resp.Msg))
+		}
+
+		if len(resp.Data.Items) == 0 {
+			return false, nil, nil
+		}
+
+		iterator.nextPageToken = resp.Data.PageToken
+		iterator.items = resp.Data.Items
+		iterator.index = 0
+	}
+
+	block := iterator.items[iterator.index]
+	iterator.index++
+	iterator.curlNum++
+	return true, block, nil
+}
+
+func (iterator *ListFileViewRecordIterator) NextPageToken() *string {
+	return iterator.nextPageToken
+}
+
+type ListFileIterator struct {
+	nextPageToken *string
+	items         []*File
+	index         int
+	limit         int
+	ctx           context.Context
+	req           *ListFileReq
+	listFunc      func(ctx context.Context, req *ListFileReq, options ...larkcore.RequestOptionFunc) (*ListFileResp, error)
+	options       []larkcore.RequestOptionFunc
+	curlNum       int
+}
+
+func (iterator *ListFileIterator) Next() (bool, *File, error) {
+	// 达到最大量，则返回
+	if iterator.limit > 0 && iterator.curlNum >= iterator.limit {
+		return false, nil, nil
+	}
+
+	// 为0则拉取数据
+	if iterator.index == 0 || iterator.index >= len(iterator.items) {
+		if iterator.index != 0 && iterator.nextPageToken == nil {
+			return false, nil, nil
+		}
+		if iterator.nextPageToken != nil {
+			iterator.req.apiReq.QueryParams.Set("page_token", *iterator.nextPageToken)
+		}
+		resp, err := iterator.listFunc(iterator.ctx, iterator.req, iterator.options...)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if resp.Code != 0 {
+			return false, nil, errors.New(fmt.Sprintf("Code:%d,Msg:%s", resp.Code, resp.Msg))
+		}
+
+		if len(resp.Data.Files) == 0 {
+			return false, nil, nil
+		}
+
+		iterator.nextPageToken = resp.Data.NextPageToken
+		iterator.items = resp.Data.Files
+		iterator.index = 0
+	}
+
+	block := iterator.items[iterator.index]
+	iterator.index++
+	iterator.curlNum++
+	return true, block, nil
+}
+
+func (iterator *ListFileIterator) NextPageToken() *string {
+	return iterator.nextPageToken
+}
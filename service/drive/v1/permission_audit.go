@@ -0,0 +1,112 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// PermissionAudit 单个多维表格的权限审计结果
+type PermissionAudit struct {
+	AppToken        string            // 多维表格 app token
+	ExternalAccess  bool              // 是否允许内容被分享到组织外
+	LinkShareEntity string            // 链接分享设置，取值见 LinkShareEntity* 常量
+	Public          *PermissionPublic // 完整的公共权限设置
+	Members         []*Member         // 全部协作者及其权限
+	Err             error             // 审计该多维表格时遇到的错误，不为空时其余字段可能不完整
+}
+
+// 是否存在对组织外开放的访问途径：允许外部分享、互联网链接分享或存在外部协作者
+func (audit *PermissionAudit) HasExternalExposure() bool {
+	if audit.ExternalAccess {
+		return true
+	}
+	if audit.LinkShareEntity == LinkShareEntityAnyoneReadable || audit.LinkShareEntity == LinkShareEntityAnyoneEditable {
+		return true
+	}
+	for _, member := range audit.Members {
+		if member.ExternalLabel != nil && *member.ExternalLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// 审计多维表格的共享设置
+//
+// - 依次获取每个多维表格的公共权限设置和协作者列表
+//
+// - 单个多维表格获取失败时记录在对应结果的 Err 中，不影响其余多维表格；仅 ctx 结束时返回 error
+func (d *DriveService) AuditBitablePermissions(ctx context.Context, appTokens []string, options ...larkcore.RequestOptionFunc) ([]*PermissionAudit, error) {
+	audits := make([]*PermissionAudit, 0, len(appTokens))
+	for _, appToken := range appTokens {
+		if err := ctx.Err(); err != nil {
+			return audits, err
+		}
+		audits = append(audits, d.auditBitablePermission(ctx, appToken, options...))
+	}
+	return audits, nil
+}
+
+func (d *DriveService) auditBitablePermission(ctx context.Context, appToken string, options ...larkcore.RequestOptionFunc) *PermissionAudit {
+	audit := &PermissionAudit{AppToken: appToken}
+
+	publicResp, err := d.PermissionPublic.Get(ctx, NewGetPermissionPublicReqBuilder().
+		Token(appToken).
+		Type(TokenTypeGetPermissionPublicBitable).
+		Build(), options...)
+	if err != nil {
+		audit.Err = err
+		return audit
+	}
+	if !publicResp.Success() {
+		audit.Err = publicResp.CodeError
+		return audit
+	}
+	if publicResp.Data == nil {
+		audit.Err = fmt.Errorf("get public permission of %s returned no data", appToken)
+		return audit
+	}
+	if public := publicResp.Data.PermissionPublic; public != nil {
+		audit.Public = public
+		if public.ExternalAccess != nil {
+			audit.ExternalAccess = *public.ExternalAccess
+		}
+		if public.LinkShareEntity != nil {
+			audit.LinkShareEntity = *public.LinkShareEntity
+		}
+	}
+
+	memberResp, err := d.PermissionMember.List(ctx, NewListPermissionMemberReqBuilder().
+		Token(appToken).
+		Type(TokenTypeListPermissionMemberBitable).
+		Fields("*").
+		Build(), options...)
+	if err != nil {
+		audit.Err = err
+		return audit
+	}
+	if !memberResp.Success() {
+		audit.Err = memberResp.CodeError
+		return audit
+	}
+	if memberResp.Data == nil {
+		audit.Err = fmt.Errorf("list permission members of %s returned no data", appToken)
+		return audit
+	}
+	audit.Members = memberResp.Data.Items
+	return audit
+}
@@ -0,0 +1,95 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import "strings"
+
+const (
+	ReplyElementTypeTextRun  = "text_run"  // 普通文本
+	ReplyElementTypeDocsLink = "docs_link" // at 云文档
+	ReplyElementTypePerson   = "person"    // at 联系人
+)
+
+// ReplyRichTextBuilder 按顺序拼装评论/回复的富文本内容
+//
+// 示例：
+//
+//	content := larkdrive.NewReplyRichTextBuilder().
+//		Text("请确认 ").
+//		Mention("ou_xxx").
+//		Text(" 的数据，参考 ").
+//		DocsLink("https://xxx.feishu.cn/base/xxx").
+//		Build()
+type ReplyRichTextBuilder struct {
+	elements []*ReplyElement
+}
+
+func NewReplyRichTextBuilder() *ReplyRichTextBuilder {
+	return &ReplyRichTextBuilder{}
+}
+
+// 追加一段普通文本
+func (builder *ReplyRichTextBuilder) Text(text string) *ReplyRichTextBuilder {
+	return builder.Element(NewReplyElementBuilder().
+		Type(ReplyElementTypeTextRun).
+		TextRun(NewTextRunBuilder().Text(text).Build()).
+		Build())
+}
+
+// 追加一个云文档链接
+func (builder *ReplyRichTextBuilder) DocsLink(url string) *ReplyRichTextBuilder {
+	return builder.Element(NewReplyElementBuilder().
+		Type(ReplyElementTypeDocsLink).
+		DocsLink(NewDocsLinkBuilder().Url(url).Build()).
+		Build())
+}
+
+// 追加一个 at 联系人，userId 的类型与请求中的 user_id_type 一致
+func (builder *ReplyRichTextBuilder) Mention(userId string) *ReplyRichTextBuilder {
+	return builder.Element(NewReplyElementBuilder().
+		Type(ReplyElementTypePerson).
+		Person(NewPersonBuilder().UserId(userId).Build()).
+		Build())
+}
+
+// 追加任意元素
+func (builder *ReplyRichTextBuilder) Element(element *ReplyElement) *ReplyRichTextBuilder {
+	builder.elements = append(builder.elements, element)
+	return builder
+}
+
+// 返回已拼装的元素列表
+func (builder *ReplyRichTextBuilder) Elements() []*ReplyElement {
+	return builder.elements
+}
+
+func (builder *ReplyRichTextBuilder) Build() *ReplyContent {
+	return NewReplyContentBuilder().Elements(builder.elements).Build()
+}
+
+// 将回复内容转换为纯文本，云文档链接输出 url，联系人输出 @user_id
+func (content *ReplyContent) PlainText() string {
+	var sb strings.Builder
+	for _, element := range content.Elements {
+		switch {
+		case element.TextRun != nil && element.TextRun.Text != nil:
+			sb.WriteString(*element.TextRun.Text)
+		case element.DocsLink != nil && element.DocsLink.Url != nil:
+			sb.WriteString(*element.DocsLink.Url)
+		case element.Person != nil && element.Person.UserId != nil:
+			sb.WriteString("@")
+			sb.WriteString(*element.Person.UserId)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,214 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+const (
+	SubscriptionActionNone        = "none"        // 订阅正常，无需处理
+	SubscriptionActionCreated     = "created"     // 新建了订阅
+	SubscriptionActionResubscribe = "resubscribe" // 订阅已失效，已重新开启
+)
+
+// 查询订阅状态时表示订阅关系不存在的错误码
+const ErrCodeFileSubscriptionNotFound = 1063005
+
+// SubscriptionStatus 单个多维表格的订阅检查结果
+type SubscriptionStatus struct {
+	AppToken       string // 多维表格 app token
+	SubscriptionId string // 订阅关系ID
+	Action         string // 本次执行的操作，取值见 SubscriptionAction* 常量
+	Err            error  // 检查或修复订阅时遇到的错误
+}
+
+// SubscriptionManager 保证一组多维表格始终处于订阅状态
+//
+// - 已知的订阅关系ID可通过 Track 预先登记（例如进程重启后从存储中恢复），未登记的多维表格会新建订阅
+//
+// - 订阅被关闭时重新开启，订阅不存在时重新创建
+type SubscriptionManager struct {
+	service          *DriveService
+	subscriptionType string
+	options          []larkcore.RequestOptionFunc
+
+	mu            sync.Mutex
+	subscriptions map[string]string // app token -> 订阅关系ID
+}
+
+// 创建订阅管理器，subscriptionType 取值见 SubscriptionType* 常量
+func (d *DriveService) NewSubscriptionManager(subscriptionType string, appTokens []string, options ...larkcore.RequestOptionFunc) *SubscriptionManager {
+	m := &SubscriptionManager{
+		service:          d,
+		subscriptionType: subscriptionType,
+		options:          options,
+		subscriptions:    map[string]string{},
+	}
+	for _, appToken := range appTokens {
+		m.subscriptions[appToken] = ""
+	}
+	return m
+}
+
+// 登记多维表格及其已知的订阅关系ID，subscriptionId 为空表示尚未订阅
+func (m *SubscriptionManager) Track(appToken string, subscriptionId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscriptions[appToken] = subscriptionId
+}
+
+// 不再管理该多维表格的订阅，已有订阅不会被关闭
+func (m *SubscriptionManager) Untrack(appToken string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.subscriptions, appToken)
+}
+
+// 当前登记的订阅关系，key 为 app token，value 为订阅关系ID
+func (m *SubscriptionManager) Subscriptions() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subscriptions := make(map[string]string, len(m.subscriptions))
+	for appToken, subscriptionId := range m.subscriptions {
+		subscriptions[appToken] = subscriptionId
+	}
+	return subscriptions
+}
+
+// 检查并修复全部多维表格的订阅
+//
+// - 单个多维表格处理失败时记录在对应结果的 Err 中；仅 ctx 结束时返回 error
+func (m *SubscriptionManager) Ensure(ctx context.Context) ([]*SubscriptionStatus, error) {
+	subscriptions := m.Subscriptions()
+	statuses := make([]*SubscriptionStatus, 0, len(subscriptions))
+	for appToken, subscriptionId := range subscriptions {
+		if err := ctx.Err(); err != nil {
+			return statuses, err
+		}
+		status := m.ensure(ctx, appToken, subscriptionId)
+		if status.Err == nil && status.SubscriptionId != subscriptionId {
+			m.mu.Lock()
+			if _, ok := m.subscriptions[appToken]; ok {
+				m.subscriptions[appToken] = status.SubscriptionId
+			}
+			m.mu.Unlock()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// 每隔 interval 执行一次 Ensure，直到 ctx 结束
+//
+// - onStatus 不为空时，每轮检查结束后回调本轮结果
+func (m *SubscriptionManager) Run(ctx context.Context, interval time.Duration, onStatus func([]*SubscriptionStatus)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		statuses, err := m.Ensure(ctx)
+		if err != nil {
+			return err
+		}
+		if onStatus != nil {
+			onStatus(statuses)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *SubscriptionManager) ensure(ctx context.Context, appToken string, subscriptionId string) *SubscriptionStatus {
+	status := &SubscriptionStatus{AppToken: appToken, SubscriptionId: subscriptionId, Action: SubscriptionActionNone}
+	if subscriptionId == "" {
+		return m.create(ctx, status)
+	}
+
+	getResp, err := m.service.FileSubscription.Get(ctx, NewGetFileSubscriptionReqBuilder().
+		FileToken(appToken).
+		SubscriptionId(subscriptionId).
+		FileSubscription(NewFileSubscriptionBuilder().
+			FileType(TypeBitable).
+			Build()).
+		Build(), m.options...)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	if !getResp.Success() {
+		// 订阅关系已不存在，重新创建
+		if getResp.Code == ErrCodeFileSubscriptionNotFound {
+			return m.create(ctx, status)
+		}
+		status.Err = getResp.CodeError
+		return status
+	}
+	if getResp.Data == nil {
+		status.Err = fmt.Errorf("get subscription %s of %s returned no data", subscriptionId, appToken)
+		return status
+	}
+	if getResp.Data.IsSubcribe != nil && *getResp.Data.IsSubcribe {
+		return status
+	}
+
+	patchResp, err := m.service.FileSubscription.Patch(ctx, NewPatchFileSubscriptionReqBuilder().
+		FileToken(appToken).
+		SubscriptionId(subscriptionId).
+		Body(NewPatchFileSubscriptionReqBodyBuilder().
+			IsSubscribe(true).
+			FileType(TypeBitable).
+			Build()).
+		Build(), m.options...)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	if !patchResp.Success() {
+		status.Err = patchResp.CodeError
+		return status
+	}
+	status.Action = SubscriptionActionResubscribe
+	return status
+}
+
+func (m *SubscriptionManager) create(ctx context.Context, status *SubscriptionStatus) *SubscriptionStatus {
+	resp, err := m.service.FileSubscription.Create(ctx, NewCreateFileSubscriptionReqBuilder().
+		FileToken(status.AppToken).
+		FileSubscription(NewFileSubscriptionBuilder().
+			SubscriptionType(m.subscriptionType).
+			IsSubcribe(true).
+			FileType(TypeBitable).
+			Build()).
+		Build(), m.options...)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	if !resp.Success() {
+		status.Err = resp.CodeError
+		return status
+	}
+	if resp.Data != nil && resp.Data.SubscriptionId != nil {
+		status.SubscriptionId = *resp.Data.SubscriptionId
+	}
+	status.Action = SubscriptionActionCreated
+	return status
+}
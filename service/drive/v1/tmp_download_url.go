@@ -0,0 +1,67 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"context"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 单次获取临时下载链接的 file_token 数上限
+const tmpDownloadUrlBatchLimit = 5
+
+// 批量获取素材临时下载链接
+//
+// - 按单次 5 个自动分批请求，重复的 file_token 只请求一次
+//
+// - 返回值以 file_token 为 key，未返回链接的 file_token 不在结果中
+func (m *media) BatchGetTmpDownloadUrls(ctx context.Context, fileTokens []string, extra string, options ...larkcore.RequestOptionFunc) (map[string]string, error) {
+	unique := make([]string, 0, len(fileTokens))
+	seen := make(map[string]bool, len(fileTokens))
+	for _, fileToken := range fileTokens {
+		if fileToken == "" || seen[fileToken] {
+			continue
+		}
+		seen[fileToken] = true
+		unique = append(unique, fileToken)
+	}
+
+	urls := make(map[string]string, len(unique))
+	for start := 0; start < len(unique); start += tmpDownloadUrlBatchLimit {
+		end := start + tmpDownloadUrlBatchLimit
+		if end > len(unique) {
+			end = len(unique)
+		}
+		builder := NewBatchGetTmpDownloadUrlMediaReqBuilder().FileTokens(unique[start:end])
+		if extra != "" {
+			builder.Extra(extra)
+		}
+		resp, err := m.BatchGetTmpDownloadUrl(ctx, builder.Build(), options...)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success() {
+			return nil, resp.CodeError
+		}
+		if resp.Data == nil {
+			continue
+		}
+		for _, url := range resp.Data.TmpDownloadUrls {
+			if url.FileToken != nil && url.TmpDownloadUrl != nil {
+				urls[*url.FileToken] = *url.TmpDownloadUrl
+			}
+		}
+	}
+	return urls, nil
+}
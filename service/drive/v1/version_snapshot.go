@@ -0,0 +1,71 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2022 Lark Technologies Pte. Ltd.
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice, shall be included in all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+ */
+
+package larkdrive
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/larksuite/base-sdk-go/v3/core"
+)
+
+// 快照版本名前缀，完整名称为前缀加创建时间
+const versionSnapshotNamePrefix = "snapshot-"
+
+// 为多维表格创建版本快照后再执行破坏性操作
+//
+// - 快照名称为 snapshot-<yyyyMMdd-HHmmss>，快照创建失败时不会执行 fn
+//
+// - 无论 fn 是否返回错误，都会返回已创建的版本，调用方可据此在校验失败时回滚或对比
+//
+// - fn 的错误原样返回
+func (f *fileVersion) WithVersionSnapshot(ctx context.Context, appToken string, fn func() error, options ...larkcore.RequestOptionFunc) (*Version, error) {
+	name := versionSnapshotNamePrefix + time.Now().Format("20060102-150405")
+	return f.WithNamedVersionSnapshot(ctx, appToken, name, fn, options...)
+}
+
+// 与 WithVersionSnapshot 相同，但使用指定的快照名称
+func (f *fileVersion) WithNamedVersionSnapshot(ctx context.Context, appToken string, name string, fn func() error, options ...larkcore.RequestOptionFunc) (*Version, error) {
+	resp, err := f.Create(ctx, NewCreateFileVersionReqBuilder().
+		FileToken(appToken).
+		Version(NewVersionBuilder().
+			Name(name).
+			ObjType(ObjTypeBitable).
+			Build()).
+		Build(), options...)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success() {
+		return nil, resp.CodeError
+	}
+
+	data := resp.Data
+	if data == nil {
+		return nil, fmt.Errorf("create version %s returned no data", name)
+	}
+	version := &Version{
+		Name:        data.Name,
+		Version:     data.Version,
+		ParentToken: data.ParentToken,
+		OwnerId:     data.OwnerId,
+		CreatorId:   data.CreatorId,
+		CreateTime:  data.CreateTime,
+		UpdateTime:  data.UpdateTime,
+		Status:      data.Status,
+		ObjType:     data.ObjType,
+		ParentType:  data.ParentType,
+	}
+	return version, fn()
+}